@@ -0,0 +1,57 @@
+package go_runner
+
+import (
+	"context"
+	"time"
+)
+
+type (
+	// appKey ключ сведений о приложении в контексте его вызовов
+	appKey struct{}
+
+	// appInfo сведения о приложении, вызываемом с контекстом
+	appInfo struct {
+		name  string
+		phase Phase
+	}
+
+	// deadlineContext контекст без отмены родителя, сообщающий его дедлайн
+	deadlineContext struct {
+		context.Context
+		parent context.Context
+	}
+)
+
+// withoutCancel возвращает контекст со значениями и дедлайном ctx, который не
+// отменяется вместе с ctx: приложения видят дедлайн Run, а их контекст
+// отменяется Runner после паузы WithDrainDelay.
+func withoutCancel(ctx context.Context) context.Context {
+	return deadlineContext{Context: context.WithoutCancel(ctx), parent: ctx}
+}
+
+// Deadline возвращает дедлайн родительского контекста.
+func (c deadlineContext) Deadline() (time.Time, bool) {
+	return c.parent.Deadline()
+}
+
+// AppNameFromContext возвращает имя приложения из контекста, переданного в его
+// Start, Warmup, Drain, Stop, HealthCheck или в shutdown hook. Для других
+// контекстов возвращает false.
+func AppNameFromContext(ctx context.Context) (string, bool) {
+	info, ok := ctx.Value(appKey{}).(appInfo)
+	return info.name, ok
+}
+
+// AppPhaseFromContext возвращает этап жизненного цикла, на котором вызвано
+// приложение: PhaseStart для Start и Warmup, PhaseStop для Drain и Stop,
+// PhaseHook для shutdown hooks, PhaseHealth для HealthCheck. Для других
+// контекстов возвращает пустую строку.
+func AppPhaseFromContext(ctx context.Context) Phase {
+	info, _ := ctx.Value(appKey{}).(appInfo)
+	return info.phase
+}
+
+// withApp добавляет в контекст сведения о приложении с индексом i.
+func (r *Runner) withApp(ctx context.Context, i int, phase Phase) context.Context {
+	return context.WithValue(ctx, appKey{}, appInfo{name: r.apps[i].Name, phase: phase})
+}
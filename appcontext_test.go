@@ -0,0 +1,111 @@
+package go_runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppNameFromContext(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	var calls []string
+	record := func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		name, ok := AppNameFromContext(ctx)
+		assert.True(t, ok)
+		calls = append(calls, name+":"+string(AppPhaseFromContext(ctx)))
+	}
+	appMock.On("Start", mock.Anything).Run(record).Return(nil).Once()
+	appMock.On("Stop", mock.Anything).Run(record).Return(nil).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("api", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"api:start", "api:stop"}, calls)
+	appMock.AssertExpectations(t)
+
+	_, ok := AppNameFromContext(context.Background())
+	assert.False(t, ok)
+	assert.Empty(t, AppPhaseFromContext(context.Background()))
+}
+
+type traceKey struct{}
+
+func TestAppsRunner_Run_WithContextDecorator(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	var traces []string
+	record := func(args mock.Arguments) {
+		trace, _ := args.Get(0).(context.Context).Value(traceKey{}).(string)
+		traces = append(traces, trace)
+	}
+	appMock.On("Start", mock.Anything).Run(record).Return(nil).Once()
+	appMock.On("Stop", mock.Anything).Run(record).Return(nil).Once()
+
+	decorate := func(suffix string) func(ctx context.Context) context.Context {
+		return func(ctx context.Context) context.Context {
+			trace, _ := ctx.Value(traceKey{}).(string)
+			return context.WithValue(ctx, traceKey{}, trace+suffix)
+		}
+	}
+
+	runner := New(discardLogger{}, WithContextDecorator(decorate("a")), WithContextDecorator(decorate("b")))
+	runner.RegisterContextApp("api", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Декораторы применяются в порядке регистрации, значения доступны и в Stop
+	assert.Equal(t, []string{"ab", "ab"}, traces)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_WithContextDecoratorDeadline(t *testing.T) {
+	deadline := time.Now().Add(time.Hour)
+
+	var seen time.Time
+	var ok bool
+	appMock := &MockContextApp{}
+	appMock.On("Start", mock.Anything).Run(func(args mock.Arguments) {
+		seen, ok = args.Get(0).(context.Context).Deadline()
+	}).Return(nil).Once()
+	appMock.On("Stop", mock.Anything).Return(nil).Once()
+
+	var cancelDeadline context.CancelFunc
+	runner := New(discardLogger{}, WithContextDecorator(func(ctx context.Context) context.Context {
+		ctx, cancelDeadline = context.WithDeadline(ctx, deadline)
+		return ctx
+	}))
+	runner.RegisterContextApp("api", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	cancelDeadline()
+
+	// Дедлайн декоратора виден в Start
+	require.True(t, ok)
+	assert.True(t, seen.Equal(deadline))
+	appMock.AssertExpectations(t)
+}
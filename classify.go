@@ -0,0 +1,32 @@
+package go_runner
+
+// Серьезность ошибки Start или Stop
+const (
+	// SeverityFatal ошибка обрабатывается как обычно
+	SeverityFatal Severity = iota
+	// SeverityWarn ошибка логируется как предупреждение и считается успехом
+	SeverityWarn
+	// SeverityIgnore ошибка считается успехом
+	SeverityIgnore
+)
+
+// Severity серьезность ошибки, которую возвращает классификатор WithErrorClassifier
+type Severity int
+
+// classify применяет классификатор WithErrorClassifier к ошибке Start или Stop
+// приложения с индексом i и возвращает nil для ожидаемых ошибок.
+func (r *Runner) classify(i int, err error) error {
+	if err == nil || r.classifier == nil {
+		return err
+	}
+
+	switch r.classifier(err) {
+	case SeverityIgnore:
+		return nil
+	case SeverityWarn:
+		r.logger.Warn(r.messages.ExpectedError, r.appFields(i, r.messages.ErrorKey, err)...)
+		return nil
+	default:
+		return err
+	}
+}
@@ -0,0 +1,51 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_WithErrorClassifier(t *testing.T) {
+	stopErr := errors.New("already closed")
+	classify := func(err error) Severity {
+		switch {
+		case errors.Is(err, http.ErrServerClosed):
+			return SeverityIgnore
+		case errors.Is(err, stopErr):
+			return SeverityWarn
+		default:
+			return SeverityFatal
+		}
+	}
+
+	recorder := &callRecorder{}
+	runner := New(&recordingLogger{recorder: recorder}, WithErrorClassifier(classify))
+	runner.RegisterStartOnly("server", func() error { return http.ErrServerClosed })
+	runner.RegisterStartStop("db", func() error { return nil }, func() error { return stopErr })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	// Ожидаемые ошибки не считаются сбоем запуска
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"expected application error"}, recorder.filter("warn:"))
+	assert.True(t, runner.Summary().Apps[0].Started)
+}
+
+func TestRunner_WithErrorClassifier_Fatal(t *testing.T) {
+	startErr := errors.New("listen error")
+
+	runner := New(discardLogger{}, WithErrorClassifier(func(error) Severity { return SeverityFatal }))
+	runner.RegisterStartOnly("server", func() error { return startErr })
+
+	require.ErrorIs(t, runner.Run(context.Background()), startErr)
+}
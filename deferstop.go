@@ -0,0 +1,49 @@
+package go_runner
+
+import "context"
+
+// runnerKey ключ Runner в контексте Start
+type runnerKey struct{}
+
+// DeferStop регистрирует stop для вызова при остановке через Runner из контекста,
+// переданного в Start. Возвращает false, если контекст не получен от Runner.
+func DeferStop(ctx context.Context, stop func() error) bool {
+	r, ok := ctx.Value(runnerKey{}).(*Runner)
+	if !ok {
+		return false
+	}
+
+	r.DeferStop(stop)
+	return true
+}
+
+// DeferStop регистрирует stop для вызова при остановке текущего запуска. Функции
+// вызываются после остановки приложений и до shutdown hooks в обратном порядке
+// регистрации. Безопасен для конкурентного вызова.
+func (r *Runner) DeferStop(stop func() error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.deferred = append(r.deferred, stop)
+}
+
+// runDeferred вызывает функции DeferStop в обратном порядке регистрации и
+// возвращает последнюю ошибку.
+func (r *Runner) runDeferred() error {
+	r.mu.Lock()
+	deferred := r.deferred
+	r.deferred = nil
+	r.mu.Unlock()
+
+	var err error
+	for i := len(deferred) - 1; i >= 0; i-- {
+		r.logger.Debug(r.messages.CallingDeferredStop)
+		if stopErr := deferred[i](); stopErr != nil {
+			r.logger.Error(r.messages.DeferredStopError, r.messages.ErrorKey, stopErr)
+			r.recordFailure(PhaseHook, -1, stopErr, nil)
+			err = stopErr
+		}
+	}
+
+	return err
+}
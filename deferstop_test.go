@@ -0,0 +1,69 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeferStop(t *testing.T) {
+	recorder := &callRecorder{}
+
+	appMock := &MockContextApp{}
+	appMock.On("Start", mock.Anything).Run(func(args mock.Arguments) {
+		ctx := args.Get(0).(context.Context)
+		assert.True(t, DeferStop(ctx, func() error {
+			recorder.record("deferred:conn")
+			return nil
+		}))
+		assert.True(t, DeferStop(ctx, func() error {
+			recorder.record("deferred:pool")
+			return nil
+		}))
+	}).Return(nil).Once()
+	appMock.On("Stop", mock.Anything).Run(func(mock.Arguments) {
+		recorder.record("stop:api")
+	}).Return(nil).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("api", appMock)
+	runner.RegisterShutdownHook(func() error {
+		recorder.record("hook")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"stop:api", "deferred:pool", "deferred:conn", "hook"}, recorder.Calls())
+	appMock.AssertExpectations(t)
+
+	assert.False(t, DeferStop(context.Background(), func() error { return nil }))
+}
+
+func TestDeferStop_Error(t *testing.T) {
+	stopErr := errors.New("close error")
+
+	runner := New(discardLogger{})
+	runner.RegisterStartOnly("api", func() error {
+		runner.DeferStop(func() error { return stopErr })
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.ErrorIs(t, runner.Run(ctx), stopErr)
+}
@@ -0,0 +1,58 @@
+package go_runner
+
+import "context"
+
+// isolate останавливает домен отказа приложения i после ошибки его запуска.
+// Возвращает false, если ошибка должна остановить все приложения: приложение не
+// входит в домен, помечено WithFatal или остановлены все домены.
+func (r *Runner) isolate(ctx context.Context, i int, waves [][]int, err error) bool {
+	a := r.apps[i]
+	if a.Domain == "" || a.Fatal {
+		return false
+	}
+
+	r.mu.Lock()
+	r.downDomains[a.Domain] = true
+	all := r.allDomainsDown()
+	r.mu.Unlock()
+
+	if all {
+		return false
+	}
+
+	r.logger.Error(r.messages.DomainStopped, r.messages.DomainKey, a.Domain, r.messages.ErrorKey, err)
+
+	// Остальные приложения домена останавливаются в обратном порядке
+	stopCtx := context.WithoutCancel(ctx)
+	for _, j := range stopOrder(waves) {
+		if r.apps[j].Domain == a.Domain && r.apps[j].Stop != nil && r.needsStop(j) {
+			_ = r.stopApp(stopCtx, j)
+		}
+	}
+
+	return true
+}
+
+// domainDown сообщает, что домен отказа name остановлен.
+func (r *Runner) domainDown(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.downDomains[name]
+}
+
+// allDomainsDown сообщает, что все приложения входят в домены отказа и все эти
+// домены остановлены. Вызывается под блокировкой.
+func (r *Runner) allDomainsDown() bool {
+	for _, a := range r.apps {
+		if a.Start != nil && !r.downDomains[a.Domain] {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,62 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_Run_FailureDomain(t *testing.T) {
+	recorder := &callRecorder{}
+	startErr := errors.New("billing api down")
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("billing-db", &recordingApp{name: "billing-db", recorder: recorder},
+		WithFailureDomain("billing"))
+	runner.RegisterStartOnly("billing-api", func() error { return startErr },
+		WithFailureDomain("billing"), DependsOn("billing-db"))
+	runner.RegisterNamedApp("search", &recordingApp{name: "search", recorder: recorder},
+		WithFailureDomain("search"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+
+	// Домен billing остановлен, search продолжает работу
+	select {
+	case <-runner.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("runner not running after domain failure")
+	}
+	assert.Equal(t, []string{"billing-db"}, recorder.filter("stop:"))
+	assert.Equal(t, StateRunning, runner.State())
+
+	cancel()
+	err := <-errCh
+	require.ErrorIs(t, err, startErr)
+	assert.Equal(t, []string{"billing-db", "search"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_FailureDomainFatal(t *testing.T) {
+	recorder := &callRecorder{}
+	startErr := errors.New("billing api down")
+
+	runner := New(discardLogger{})
+	runner.RegisterStartOnly("billing-api", func() error { return startErr },
+		WithFailureDomain("billing"), WithFatal(), WithPriority(-1))
+	runner.RegisterNamedApp("search", &recordingApp{name: "search", recorder: recorder},
+		WithFailureDomain("search"))
+
+	// Ошибка фатального приложения останавливает все домены
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, startErr)
+	require.NoError(t, ctx.Err(), "run was not stopped by the fatal error")
+	assert.Equal(t, []string{"search"}, recorder.filter("stop:"))
+}
@@ -1,7 +1,63 @@
 package go_runner
 
-import "errors"
+import (
+	"errors"
+	"os"
+)
 
+// Причины отмены контекста Run, доступные приложениям через context.Cause
+// от контекста, переданного в Start:
+//   - *SignalError — получен сигнал завершения, errors.Is сопоставляет ее с ErrInterruptedBySignal;
+//   - ErrTriggered — сработал канал WithTriggerChannel;
+//   - ErrStartupTimeout — истекло время WithMaxStartupTime;
+//   - ErrStartFailed — приложение не запустилось, исходная ошибка доступна через errors.Is;
+//   - ErrAppExited — приложение завершилось само при WithShutdownOnAppExit;
+//   - ErrUnhealthy — приложение не прошло проверку состояния WithUnhealthyFatal;
+//   - причина отмены родительского контекста, переданного в Run.
 var (
 	ErrInterruptedBySignal = errors.New("process interrupted by signal")
+	ErrShutdownTimeout     = errors.New("shutdown timeout exceeded")
+	ErrForcedShutdown      = errors.New("shutdown forced by signal")
+	ErrStartupTimeout      = errors.New("startup timeout exceeded")
+	ErrDependencyCycle     = errors.New("dependency cycle")
+	ErrUnknownDependency   = errors.New("unknown dependency")
+	ErrDuplicateName       = errors.New("duplicate app name")
+	ErrPanic               = errors.New("application panic")
+	ErrStartFailed         = errors.New("application start failed")
+	ErrTriggered           = errors.New("shutdown triggered")
+	ErrAppExited           = errors.New("application exited")
+	ErrUnhealthy           = errors.New("application unhealthy")
+
+	// ErrContextAlreadyCancelled контекст, переданный в Run, отменен до запуска
+	ErrContextAlreadyCancelled = errors.New("context already cancelled")
+	// ErrRunning операция недоступна во время работы Run
+	ErrRunning = errors.New("runner is running")
+	// ErrNotRunning операция доступна только в состоянии StateRunning
+	ErrNotRunning = errors.New("runner is not running")
+	// ErrAppNotRunning приложение не найдено или не запущено
+	ErrAppNotRunning = errors.New("app is not running")
+	// ErrReadyTimeout приложение не сообщило о готовности за время WithReadyTimeout
+	ErrReadyTimeout = errors.New("ready timeout exceeded")
+	// ErrDrainTimeout причина отмены контекста Drain по истечении WithDrainTimeout
+	ErrDrainTimeout = errors.New("drain timeout exceeded")
+	// ErrEmptyName StartApp или StopApp вызваны с пустым именем приложения
+	ErrEmptyName = errors.New("empty app name")
+	// ErrStopConstraint WithStopBefore или WithStopAfter заданы вместе с порядком
+	// остановки, отличным от StopReverse
+	ErrStopConstraint = errors.New("stop constraints require reverse stop ordering")
 )
+
+// SignalError остановка по сигналу. errors.Is сопоставляет ее с ErrInterruptedBySignal,
+// а errors.As позволяет получить сигнал.
+type SignalError struct {
+	Signal os.Signal
+}
+
+func (e *SignalError) Error() string {
+	return ErrInterruptedBySignal.Error() + ": " + e.Signal.String()
+}
+
+// Is сообщает, что ошибка соответствует ErrInterruptedBySignal.
+func (e *SignalError) Is(target error) bool {
+	return target == ErrInterruptedBySignal
+}
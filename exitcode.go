@@ -0,0 +1,98 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"syscall"
+)
+
+// Результат Run при остановке по сигналу
+const (
+	// SignalAsNil остановка по сигналу считается штатной, Run возвращает nil
+	SignalAsNil SignalMode = iota
+	// SignalAsError Run возвращает *SignalError
+	SignalAsError
+	// SignalAsExitCode Run возвращает *ExitCodeError с кодом 128+номер сигнала
+	SignalAsExitCode
+)
+
+type (
+	// SignalMode результат Run при остановке по сигналу
+	SignalMode int
+
+	// ExitCodeError остановка по сигналу с кодом завершения процесса по соглашению
+	// оболочки: 128+номер сигнала. Оборачивает *SignalError, поэтому errors.Is
+	// сопоставляет ее с ErrInterruptedBySignal.
+	ExitCodeError struct {
+		Signal os.Signal
+		Code   int
+	}
+)
+
+func (e *ExitCodeError) Error() string {
+	return fmt.Sprintf("%s: %s (exit code %d)", ErrInterruptedBySignal, e.Signal, e.Code)
+}
+
+// Unwrap возвращает *SignalError с сигналом остановки.
+func (e *ExitCodeError) Unwrap() error {
+	return &SignalError{Signal: e.Signal}
+}
+
+// signalResult возвращает результат Run при штатной остановке по сигналу
+// в соответствии с WithSignalMode.
+func (r *Runner) signalResult() error {
+	r.mu.Lock()
+	sig := r.signal
+	r.mu.Unlock()
+
+	switch r.signalMode {
+	case SignalAsError:
+		return &SignalError{Signal: sig}
+	case SignalAsExitCode:
+		code := 1
+		if s, ok := sig.(syscall.Signal); ok {
+			code = 128 + int(s)
+		}
+		return &ExitCodeError{Signal: sig, Code: code}
+	default:
+		return nil
+	}
+}
+
+// ExitCode возвращает код завершения процесса для результата Run: 0 для nil,
+// код *ExitCodeError, 128+номер сигнала для *SignalError и 1 для остальных ошибок.
+func ExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	var exitErr *ExitCodeError
+	if errors.As(err, &exitErr) {
+		return exitErr.Code
+	}
+
+	var sigErr *SignalError
+	if errors.As(err, &sigErr) {
+		if s, ok := sigErr.Signal.(syscall.Signal); ok {
+			return 128 + int(s)
+		}
+	}
+
+	return 1
+}
+
+// RunAndExit вызывает Run и завершает процесс с кодом ExitCode его результата,
+// сокращая main до одной строки. При SignalAsNil остановка по сигналу
+// завершает процесс с кодом 0; код сигнала возвращается при SignalAsError и
+// SignalAsExitCode. С WithTerminationHandler процесс не завершается: результат
+// обрабатывает обработчик.
+func (r *Runner) RunAndExit(ctx context.Context) {
+	err := r.Run(ctx)
+	if r.terminationHandler != nil {
+		return
+	}
+
+	r.exit(ExitCode(err))
+}
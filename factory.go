@@ -0,0 +1,61 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+)
+
+// errNotBuilt возвращается из Start приложения RegisterFactory, если фабрика не
+// была вызвана. В Run не возникает: фабрики вызываются до запуска приложений.
+var errNotBuilt = errors.New("app is not built")
+
+// RegisterFactory регистрирует приложение, которое создается функцией build в
+// начале Run — после проверки конфигурации и до запуска первого приложения, —
+// а не при регистрации. Это позволяет не создавать дорогие приложения, если
+// запуск не состоится. Ошибка build прерывает Run до запуска приложений.
+// При повторном Run после Reset приложение создается заново.
+func (r *Runner) RegisterFactory(name string, build func(ctx context.Context) (App, error), opts ...AppOption) {
+	if build == nil {
+		return
+	}
+
+	r.register(appStruct{
+		Name: name,
+		Start: func(context.Context) error {
+			return errNotBuilt
+		},
+		Build: build,
+	}, opts)
+}
+
+// buildApps вызывает фабрики RegisterFactory в порядке регистрации и подставляет
+// созданные приложения. Вызывается до запуска приложений.
+func (r *Runner) buildApps(ctx context.Context) error {
+	for i, a := range r.apps {
+		if a.Build == nil {
+			continue
+		}
+
+		r.logger.Debug(r.messages.BuildApplication, r.appFields(i)...)
+
+		instance, err := a.Build(ctx)
+		if err == nil && instance == nil {
+			err = errors.New("factory returned nil app")
+		}
+		if err != nil {
+			r.updateState(i, func(st *appState) { st.startErr = err })
+			return r.appError(i, "build", err)
+		}
+
+		// HealthHandler читает приложения конкурентно с Run
+		r.mu.Lock()
+		r.apps[i].Start = ignoreContext(instance.Start)
+		r.apps[i].Stop = ignoreContext(instance.Stop)
+		r.apps[i].Drain = drainer(instance)
+		r.apps[i].Warmup = warmer(instance)
+		r.apps[i].HealthCheck = healthChecker(instance)
+		r.mu.Unlock()
+	}
+
+	return nil
+}
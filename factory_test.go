@@ -0,0 +1,84 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_RegisterFactory(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("db", &recordingApp{name: "db", recorder: recorder})
+	runner.RegisterFactory("api", func(context.Context) (App, error) {
+		recorder.record("build:api")
+		return &recordingApp{name: "api", recorder: recorder}, nil
+	}, DependsOn("db"))
+
+	// Приложение создается только при Run
+	assert.Empty(t, recorder.Calls())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"build:api", "start:db", "start:api", "stop:api", "stop:db"}, recorder.Calls())
+}
+
+func TestAppsRunner_RegisterFactory_Error(t *testing.T) {
+	recorder := &callRecorder{}
+	buildErr := errors.New("missing config")
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("db", &recordingApp{name: "db", recorder: recorder})
+	runner.RegisterFactory("api", func(context.Context) (App, error) {
+		return nil, buildErr
+	})
+
+	// Ошибка фабрики прерывает Run до запуска приложений
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, buildErr)
+	assert.EqualError(t, err, `app "api" build: missing config`)
+	assert.Empty(t, recorder.Calls())
+}
+
+func TestAppsRunner_RegisterFactory_ConcurrentHealth(t *testing.T) {
+	polling := make(chan struct{})
+
+	runner := New(discardLogger{})
+	runner.RegisterFactory("api", func(context.Context) (App, error) {
+		<-polling
+		return &recordingApp{name: "api", recorder: &callRecorder{}}, nil
+	})
+	handler := runner.HealthHandler()
+
+	// HealthHandler опрашивается, пока фабрика подставляет приложение
+	ctx, cancel := context.WithCancel(context.Background())
+	polled := make(chan struct{})
+	go func() {
+		defer close(polled)
+		var once sync.Once
+		for ctx.Err() == nil {
+			handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/health", nil))
+			once.Do(func() { close(polling) })
+		}
+	}()
+
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	<-polled
+}
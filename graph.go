@@ -0,0 +1,52 @@
+package go_runner
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GraphDOT возвращает граф приложений в формате Graphviz DOT, например для
+// `dot -Tsvg`. Сплошное ребро ведет от зависимости к зависимому приложению
+// (порядок запуска), пунктирное — от приложения, останавливаемого раньше, к
+// останавливаемому позже (WithStopBefore и WithStopAfter). Приоритеты запуска и
+// остановки выводятся в подписи узла. Граф строится по зарегистрированным
+// приложениям без проверки конфигурации, ошибки которой возвращает Plan.
+func (r *Runner) GraphDOT() string {
+	var b strings.Builder
+	b.WriteString("digraph runner {\n")
+
+	for i, a := range r.apps {
+		if a.Start == nil {
+			continue
+		}
+
+		label := r.label(i)
+		if a.Priority != 0 {
+			label += fmt.Sprintf("\npriority %d", a.Priority)
+		}
+		if a.StopOrdered {
+			label += fmt.Sprintf("\nstop order %d", a.StopPriority)
+		}
+		fmt.Fprintf(&b, "\t%q [label=%q];\n", r.label(i), label)
+	}
+
+	for i, a := range r.apps {
+		if a.Start == nil {
+			continue
+		}
+
+		for _, dep := range a.DependsOn {
+			fmt.Fprintf(&b, "\t%q -> %q;\n", dep, r.label(i))
+		}
+		for _, name := range a.StopBefore {
+			fmt.Fprintf(&b, "\t%q -> %q [style=dashed];\n", r.label(i), name)
+		}
+		for _, name := range a.StopAfter {
+			fmt.Fprintf(&b, "\t%q -> %q [style=dashed];\n", name, r.label(i))
+		}
+	}
+
+	b.WriteString("}\n")
+
+	return b.String()
+}
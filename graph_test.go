@@ -0,0 +1,23 @@
+package go_runner
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAppsRunner_GraphDOT(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", &MockApp{}, DependsOn("db", "cache"))
+	runner.RegisterNamedApp("db", &MockApp{}, WithPriority(1))
+	runner.RegisterNamedApp("cache", &MockApp{}, WithStopAfter("api"))
+
+	dot := runner.GraphDOT()
+
+	assert.Contains(t, dot, "digraph runner {")
+	assert.Contains(t, dot, `"api" [label="api"];`)
+	assert.Contains(t, dot, `"db" [label="db\npriority 1"];`)
+	assert.Contains(t, dot, `"db" -> "api";`)
+	assert.Contains(t, dot, `"cache" -> "api";`)
+	assert.Contains(t, dot, `"api" -> "cache" [style=dashed];`)
+}
@@ -0,0 +1,74 @@
+package go_runner
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+type (
+	// healthResponse тело ответа HealthHandler
+	healthResponse struct {
+		State State       `json:"state"`
+		Apps  []appHealth `json:"apps"`
+	}
+
+	// appHealth состояние отдельного приложения
+	appHealth struct {
+		Name  string `json:"name"`
+		State string `json:"state"`
+	}
+)
+
+// HealthHandler возвращает http.Handler, отвечающий 200, когда все приложения
+// запущены, и 503 во время запуска, остановки и вне Run. Тело ответа — JSON
+// с состоянием Runner и каждого приложения. Обработчик не запускает собственный
+// сервер и монтируется в mux пользователя.
+func (r *Runner) HealthHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		resp := r.health()
+
+		status := http.StatusServiceUnavailable
+		if resp.State == StateRunning {
+			status = http.StatusOK
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(resp)
+	})
+}
+
+// health собирает состояние Runner и приложений.
+func (r *Runner) health() healthResponse {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	resp := healthResponse{
+		State: r.lifecycle,
+		Apps:  make([]appHealth, 0, len(r.apps)),
+	}
+
+	for i, a := range r.apps {
+		// Shutdown hook не является приложением
+		if a.Start == nil {
+			continue
+		}
+
+		state := "pending"
+		if i < len(r.states) {
+			st := r.states[i]
+			switch {
+			case st.stopped:
+				state = "stopped"
+			case st.started:
+				state = "started"
+			case st.startErr != nil:
+				state = "failed"
+			}
+		}
+
+		resp.Apps = append(resp.Apps, appHealth{Name: r.label(i), State: state})
+	}
+
+	return resp
+}
@@ -0,0 +1,81 @@
+package go_runner
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// probe выполняет запрос к обработчику и возвращает код ответа и тело
+func probe(t *testing.T, h http.Handler) (int, healthResponse) {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/health", nil))
+
+	var body healthResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return rec.Code, body
+}
+
+func TestAppsRunner_HealthHandler(t *testing.T) {
+	appMock := &MockApp{}
+
+	starting := make(chan struct{})
+	releaseStart := make(chan struct{})
+	stopping := make(chan struct{})
+	releaseStop := make(chan struct{})
+
+	appMock.On("Start").Run(func(mock.Arguments) {
+		close(starting)
+		<-releaseStart
+	}).Return(nil)
+	appMock.On("Stop").Run(func(mock.Arguments) {
+		close(stopping)
+		<-releaseStop
+	}).Return(nil)
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", appMock)
+	handler := runner.HealthHandler()
+
+	code, body := probe(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StateIdle, body.State)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+
+	<-starting
+	code, body = probe(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StateStarting, body.State)
+	assert.Equal(t, []appHealth{{Name: "api", State: "pending"}}, body.Apps)
+
+	close(releaseStart)
+	require.Eventually(t, func() bool { return runner.State() == StateRunning }, time.Second, time.Millisecond)
+	code, body = probe(t, handler)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []appHealth{{Name: "api", State: "started"}}, body.Apps)
+
+	cancel()
+	<-stopping
+	code, body = probe(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StateStopping, body.State)
+
+	close(releaseStop)
+	require.NoError(t, <-errCh)
+	code, body = probe(t, handler)
+	assert.Equal(t, http.StatusServiceUnavailable, code)
+	assert.Equal(t, StateStopped, body.State)
+	assert.Equal(t, []appHealth{{Name: "api", State: "stopped"}}, body.Apps)
+}
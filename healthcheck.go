@@ -0,0 +1,82 @@
+package go_runner
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// defaultHealthInterval интервал проверок HealthChecker по умолчанию
+const defaultHealthInterval = 10 * time.Second
+
+// HealthChecker приложение, сообщающее о своем состоянии. Runner вызывает
+// HealthCheck с интервалом WithHealthCheckInterval после запуска всех приложений,
+// если для приложения задан WithUnhealthyFatal.
+type HealthChecker interface {
+	HealthCheck(ctx context.Context) error
+}
+
+// healthChecker возвращает HealthCheck приложения, если оно реализует HealthChecker.
+func healthChecker(instance any) contextCallback {
+	if h, ok := instance.(HealthChecker); ok {
+		return h.HealthCheck
+	}
+
+	return nil
+}
+
+// monitorHealth запускает проверки состояния приложений с WithUnhealthyFatal.
+// Проверки прекращаются при отмене ctx.
+func (r *Runner) monitorHealth(ctx context.Context, cancel context.CancelCauseFunc) {
+	for i, a := range r.apps {
+		if a.HealthCheck == nil || a.UnhealthyThreshold <= 0 {
+			continue
+		}
+
+		go r.watchHealth(ctx, cancel, i)
+	}
+}
+
+// watchHealth проверяет состояние приложения i и начинает остановку всех
+// приложений после UnhealthyThreshold неудачных проверок подряд.
+func (r *Runner) watchHealth(ctx context.Context, cancel context.CancelCauseFunc, i int) {
+	interval := r.healthInterval
+	if interval <= 0 {
+		interval = defaultHealthInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	threshold := r.apps[i].UnhealthyThreshold
+	failures := 0
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		err := r.safeCall(r.withApp(ctx, i, PhaseHealth), i, r.apps[i].HealthCheck)
+		if err == nil {
+			failures = 0
+			continue
+		}
+		if ctx.Err() != nil {
+			return
+		}
+
+		failures++
+		r.logger.Warn(r.messages.HealthCheckFailed, r.appFields(i, r.messages.FailuresKey, failures, r.messages.ErrorKey, err)...)
+		if failures < threshold {
+			continue
+		}
+
+		unhealthy := fmt.Errorf("%w: %d consecutive health check failures: %w", ErrUnhealthy, failures, err)
+		wrapped := r.appError(i, "health", unhealthy)
+		r.logger.Error(r.messages.ApplicationUnhealthy, r.appFields(i, r.messages.ErrorKey, err)...)
+		r.recordFailure(PhaseHealth, i, unhealthy, wrapped)
+		cancel(wrapped)
+		return
+	}
+}
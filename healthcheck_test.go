@@ -0,0 +1,67 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// unhealthyApp приложение, проверка состояния которого всегда неудачна
+type unhealthyApp struct {
+	recordingApp
+	checks atomic.Int32
+	phase  atomic.Value
+}
+
+func (a *unhealthyApp) HealthCheck(ctx context.Context) error {
+	a.checks.Add(1)
+	a.phase.Store(AppPhaseFromContext(ctx))
+	return errors.New("dependency down")
+}
+
+func TestAppsRunner_Run_WithUnhealthyFatal(t *testing.T) {
+	recorder := &callRecorder{}
+	sick := &unhealthyApp{recordingApp: recordingApp{name: "db", recorder: recorder}}
+
+	runner := New(discardLogger{}, WithHealthCheckInterval(5*time.Millisecond))
+	runner.RegisterNamedApp("db", sick, WithUnhealthyFatal(3))
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	// Третья неудачная проверка подряд останавливает все приложения
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, ErrUnhealthy)
+	assert.EqualError(t, err, `app "db" health: application unhealthy: 3 consecutive health check failures: dependency down`)
+	require.NoError(t, ctx.Err(), "run stopped by timeout instead of health check")
+
+	assert.Equal(t, int32(3), sick.checks.Load())
+	assert.ElementsMatch(t, []string{"db", "api"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_WithUnhealthyFatalPhase(t *testing.T) {
+	recorder := &callRecorder{}
+	sick := &unhealthyApp{recordingApp: recordingApp{name: "db", recorder: recorder}}
+	apiMock := &MockApp{}
+	apiMock.On("Start").Return(nil)
+	apiMock.On("Stop").Return(errors.New("close failed"))
+
+	runner := New(discardLogger{}, WithHealthCheckInterval(5*time.Millisecond))
+	runner.RegisterNamedApp("db", sick, WithUnhealthyFatal(1))
+	runner.RegisterNamedApp("api", apiMock)
+
+	// Отказ проверки состояния записывается отдельным этапом, а не как ошибка запуска
+	var runErr *RunError
+	require.ErrorAs(t, runner.Run(context.Background()), &runErr)
+	require.Len(t, runErr.Failures, 2)
+	assert.Equal(t, PhaseHealth, runErr.Failures[0].Phase)
+	assert.Equal(t, "db", runErr.Failures[0].App)
+	assert.Contains(t, runErr.Error(), "health db: application unhealthy")
+	assert.Equal(t, PhaseHealth, sick.phase.Load())
+}
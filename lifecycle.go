@@ -0,0 +1,23 @@
+package go_runner
+
+// Lifecycle описание приложения для декларативной регистрации
+type Lifecycle struct {
+	Name  string
+	Start func() error
+	Stop  func() error
+
+	// Options опции регистрации приложения
+	Options []AppOption
+}
+
+// NewFromLifecycles создает Runner и регистрирует приложения из lifecycles в
+// переданном порядке так же, как RegisterStartStop: без Start запись
+// регистрируется как shutdown hook, пустая запись пропускается.
+func NewFromLifecycles(logger Logger, lifecycles []Lifecycle, opts ...Option) *Runner {
+	r := New(logger, opts...)
+	for _, l := range lifecycles {
+		r.RegisterStartStop(l.Name, l.Start, l.Stop, l.Options...)
+	}
+
+	return r
+}
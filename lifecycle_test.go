@@ -0,0 +1,51 @@
+package go_runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewFromLifecycles(t *testing.T) {
+	recorder := &callRecorder{}
+	lifecycle := func(name string, opts ...AppOption) Lifecycle {
+		return Lifecycle{
+			Name: name,
+			Start: func() error {
+				recorder.record("start:" + name)
+				return nil
+			},
+			Stop: func() error {
+				recorder.record("stop:" + name)
+				return nil
+			},
+			Options: opts,
+		}
+	}
+
+	runner := NewFromLifecycles(discardLogger{}, []Lifecycle{
+		lifecycle("api", DependsOn("db")),
+		lifecycle("db"),
+		{Name: "flush", Stop: func() error {
+			recorder.record("hook:flush")
+			return nil
+		}},
+		{Name: "empty"},
+	})
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"db"}, {"api"}}, plan.Start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"start:db", "start:api", "stop:api", "stop:db", "hook:flush"}, recorder.Calls())
+}
@@ -6,3 +6,99 @@ type Logger interface {
 	Info(msg string, args ...any)
 	Warn(msg string, args ...any)
 }
+
+// Уровни подробности сообщений Runner
+const (
+	// VerbosityNormal уровни сообщений не меняются
+	VerbosityNormal Verbosity = iota
+	// VerbosityQuiet выводятся только ошибки и итоговая строка о завершении
+	VerbosityQuiet
+	// VerbosityVerbose сообщения Debug выводятся как Info
+	VerbosityVerbose
+)
+
+// Verbosity уровень подробности сообщений Runner
+type Verbosity int
+
+// NopLogger логгер, отбрасывающий все сообщения
+type NopLogger struct{}
+
+func (NopLogger) Debug(string, ...any) {}
+func (NopLogger) Error(string, ...any) {}
+func (NopLogger) Info(string, ...any)  {}
+func (NopLogger) Warn(string, ...any)  {}
+
+// SetLogger заменяет логгер, переданный в New, например, когда логгер создается
+// позже Runner. nil заменяется на NopLogger. Вызов после начала Run приводит к панике.
+func (r *Runner) SetLogger(logger Logger) {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.lifecycle != StateIdle {
+		panic("go_runner: SetLogger called after Run")
+	}
+	r.logger = r.leveled(logger)
+}
+
+// verbosityLogger меняет уровни сообщений Runner в соответствии с WithVerbosity
+type verbosityLogger struct {
+	Logger
+	verbosity Verbosity
+	// summary итоговое сообщение, которое выводится и при VerbosityQuiet
+	summary string
+}
+
+func (l *verbosityLogger) Debug(msg string, args ...any) {
+	switch l.verbosity {
+	case VerbosityQuiet:
+	case VerbosityVerbose:
+		l.Logger.Info(msg, args...)
+	default:
+		l.Logger.Debug(msg, args...)
+	}
+}
+
+func (l *verbosityLogger) Info(msg string, args ...any) {
+	if l.verbosity == VerbosityQuiet && msg != l.summary {
+		return
+	}
+	l.Logger.Info(msg, args...)
+}
+
+func (l *verbosityLogger) Warn(msg string, args ...any) {
+	if l.verbosity == VerbosityQuiet {
+		return
+	}
+	l.Logger.Warn(msg, args...)
+}
+
+// Sync сбрасывает буфер исходного логгера.
+func (l *verbosityLogger) Sync() error {
+	flushLogger(l.Logger)
+	return nil
+}
+
+// leveled оборачивает logger, если WithVerbosity задает уровень, отличный от
+// VerbosityNormal.
+func (r *Runner) leveled(logger Logger) Logger {
+	if r.verbosity == VerbosityNormal {
+		return logger
+	}
+
+	return &verbosityLogger{Logger: logger, verbosity: r.verbosity, summary: r.messages.ApplicationStopped}
+}
+
+// flushLogger сбрасывает буфер логгера, если он реализует Sync() error или
+// Flush() error. Ошибка сброса игнорируется: сообщить о ней уже некуда.
+func flushLogger(l Logger) {
+	switch f := l.(type) {
+	case interface{ Sync() error }:
+		_ = f.Sync()
+	case interface{ Flush() error }:
+		_ = f.Flush()
+	}
+}
@@ -0,0 +1,181 @@
+package go_runner
+
+import "reflect"
+
+// Messages сообщения и ключи полей, которые Runner передает в Logger. Пустые
+// поля заменяются значениями по умолчанию.
+type Messages struct {
+	// AppKey ключ поля с именем приложения
+	AppKey string
+	// ErrorKey ключ поля с ошибкой
+	ErrorKey string
+	// SeqKey ключ поля с номером приложения при WithGoroutineTagging
+	SeqKey string
+	// SignalKey ключ поля с полученным сигналом
+	SignalKey string
+	// TimeoutKey ключ поля с превышенным ограничением времени
+	TimeoutKey string
+	// DelayKey ключ поля с длительностью паузы
+	DelayKey string
+	// AtKey ключ поля со временем начала и завершения остановки
+	AtKey string
+	// DurationKey ключ поля с длительностью остановки и Run
+	DurationKey string
+	// CauseKey ключ поля с причиной остановки при WithContextCancelCauseLogging
+	CauseKey string
+	// FailuresKey ключ поля с числом неудачных попыток подряд
+	FailuresKey string
+	// CooldownKey ключ поля с паузой разомкнутого выключателя
+	CooldownKey string
+	// PanicKey и StackKey ключи полей с восстановленной паникой и стеком
+	PanicKey string
+	StackKey string
+	// DomainKey ключ поля с доменом отказа
+	DomainKey string
+	// PathKey и ArgsKey ключи полей с путем и аргументами при WithReExecOnReload
+	PathKey string
+	ArgsKey string
+	// PlanStartKey и PlanStopKey ключи полей с планом при WithDryRun
+	PlanStartKey string
+	PlanStopKey  string
+	// StartedKey, StartFailedKey и StopFailedKey ключи счетчиков итогового сообщения
+	StartedKey     string
+	StartFailedKey string
+	StopFailedKey  string
+
+	StartApplication      string
+	BreakerOpen           string
+	BreakerHalfOpen       string
+	WarmupApplication     string
+	ApplicationStarted    string
+	StartCancelled        string
+	OptionalStartFailed   string
+	NeverStarted          string
+	ApplicationFinished   string
+	ApplicationExited     string
+	ApplicationPanic      string
+	ReadyCallbackError    string
+	DrainApplication      string
+	DrainError            string
+	DrainTimeout          string
+	StopApplication       string
+	StopError             string
+	ExpectedError         string
+	StopStraggler         string
+	CallingShutdownHook   string
+	ShutdownHookError     string
+	HookTimeout           string
+	CallingDeferredStop   string
+	DeferredStopError     string
+	DomainStopped         string
+	ShutdownInitiated     string
+	DrainingBeforeStop    string
+	StartupTimeout        string
+	ShutdownTimeout       string
+	SignalDeferred        string
+	ForcingShutdown       string
+	ShutdownForced        string
+	ShuttingDownBySignal  string
+	ShuttingDownByTrigger string
+	ShutdownCompleted     string
+	ReloadIgnored         string
+	Reloading             string
+	ReExecuting           string
+	ReExecSkipped         string
+	HandlingSignal        string
+	SignalHandlerError    string
+	LateFailureTolerated  string
+	BuildApplication      string
+	HealthCheckFailed     string
+	ApplicationUnhealthy  string
+	DryRun                string
+	TerminatingWithError  string
+	ApplicationStopped    string
+}
+
+// defaultMessages сообщения и ключи полей по умолчанию
+var defaultMessages = Messages{
+	AppKey:         "app",
+	ErrorKey:       "error",
+	SeqKey:         "seq",
+	SignalKey:      "signal",
+	TimeoutKey:     "timeout",
+	DelayKey:       "delay",
+	AtKey:          "at",
+	DurationKey:    "duration",
+	CauseKey:       "cause",
+	FailuresKey:    "failures",
+	CooldownKey:    "cooldown",
+	PanicKey:       "panic",
+	StackKey:       "stack",
+	DomainKey:      "domain",
+	PathKey:        "path",
+	ArgsKey:        "args",
+	PlanStartKey:   "start",
+	PlanStopKey:    "stop",
+	StartedKey:     "started",
+	StartFailedKey: "start_failed",
+	StopFailedKey:  "stop_failed",
+
+	StartApplication:      "start application",
+	BreakerOpen:           "start circuit breaker opened",
+	BreakerHalfOpen:       "start circuit breaker half-open",
+	WarmupApplication:     "warmup application",
+	ApplicationStarted:    "application started",
+	StartCancelled:        "application start cancelled",
+	OptionalStartFailed:   "optional application failed to start, continuing",
+	NeverStarted:          "application never reported started",
+	ApplicationFinished:   "application finished",
+	ApplicationExited:     "application exited",
+	ApplicationPanic:      "application panic",
+	ReadyCallbackError:    "ready callback error",
+	DrainApplication:      "drain application",
+	DrainError:            "application drain error",
+	DrainTimeout:          "application drain timeout exceeded",
+	StopApplication:       "stop application",
+	StopError:             "application stop error",
+	ExpectedError:         "expected application error",
+	StopStraggler:         "application stop straggler",
+	CallingShutdownHook:   "calling shutdown hook",
+	ShutdownHookError:     "shutdown hook error",
+	HookTimeout:           "shutdown hook timeout exceeded",
+	CallingDeferredStop:   "calling deferred stop",
+	DeferredStopError:     "deferred stop error",
+	DomainStopped:         "failure domain stopped",
+	ShutdownInitiated:     "shutdown initiated",
+	DrainingBeforeStop:    "draining before stop",
+	StartupTimeout:        "startup timeout exceeded",
+	ShutdownTimeout:       "shutdown timeout exceeded",
+	SignalDeferred:        "shutdown signal deferred until minimum uptime",
+	ForcingShutdown:       "forcing shutdown",
+	ShutdownForced:        "shutdown forced",
+	ShuttingDownBySignal:  "shutting down by signal",
+	ShuttingDownByTrigger: "shutting down by trigger",
+	ShutdownCompleted:     "shutdown completed",
+	ReloadIgnored:         "reload ignored during startup",
+	Reloading:             "reloading applications",
+	ReExecuting:           "re-executing process",
+	ReExecSkipped:         "re-exec skipped after shutdown errors",
+	HandlingSignal:        "handling signal",
+	SignalHandlerError:    "signal handler error",
+	LateFailureTolerated:  "application failed after startup, continuing",
+	BuildApplication:      "build application",
+	HealthCheckFailed:     "application health check failed",
+	ApplicationUnhealthy:  "application unhealthy, shutting down",
+	DryRun:                "dry run",
+	TerminatingWithError:  "terminating with error",
+	ApplicationStopped:    "application was stopped",
+}
+
+// withDefaults заполняет пустые поля значениями по умолчанию.
+func (m Messages) withDefaults() Messages {
+	v := reflect.ValueOf(&m).Elem()
+	d := reflect.ValueOf(defaultMessages)
+	for i := 0; i < v.NumField(); i++ {
+		if v.Field(i).String() == "" {
+			v.Field(i).Set(d.Field(i))
+		}
+	}
+
+	return m
+}
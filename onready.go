@@ -0,0 +1,36 @@
+package go_runner
+
+import (
+	"context"
+	"fmt"
+)
+
+// RegisterOnReady регистрирует функцию, которую Runner вызовет один раз за
+// запуск, когда все приложения запущены, — например, чтобы вывести адрес сервиса
+// или уведомить внешнюю систему. Функции вызываются в порядке регистрации после
+// закрытия Ready. Ошибка прерывает вызов остальных функций и останавливает все
+// приложения, как ошибка запуска.
+func (r *Runner) RegisterOnReady(fn callback) {
+	if fn == nil {
+		return
+	}
+
+	r.onReady = append(r.onReady, fn)
+}
+
+// runOnReady вызывает функции RegisterOnReady и отменяет запуск при ошибке.
+func (r *Runner) runOnReady(ctx context.Context, cancel context.CancelCauseFunc) {
+	for _, fn := range r.onReady {
+		if ctx.Err() != nil {
+			return
+		}
+
+		if err := fn(); err != nil {
+			wrapped := fmt.Errorf("ready callback: %w", err)
+			r.logger.Error(r.messages.ReadyCallbackError, r.messages.ErrorKey, err)
+			r.recordFailure(PhaseStart, -1, err, wrapped)
+			cancel(startFailed(wrapped))
+			return
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_RegisterOnReady(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	for _, name := range []string{"db", "api", "worker"} {
+		runner.RegisterNamedApp(name, &recordingApp{name: name, recorder: recorder, startDelay: 10 * time.Millisecond})
+	}
+	runner.RegisterOnReady(func() error {
+		recorder.record("ready")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return len(recorder.filter("ready")) > 0 }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Вызывается один раз после запуска всех приложений
+	calls := recorder.Calls()
+	require.Len(t, recorder.filter("ready"), 1)
+	ready := slices.Index(calls, "ready")
+	for _, name := range recorder.filter("started:") {
+		assert.Less(t, slices.Index(calls, "started:"+name), ready)
+	}
+	assert.Len(t, recorder.filter("started:"), 3)
+}
+
+func TestRunner_RegisterOnReady_Error(t *testing.T) {
+	readyErr := errors.New("notify failed")
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	runner.RegisterOnReady(func() error { return readyErr })
+	runner.RegisterOnReady(func() error {
+		t.Error("callback called after error")
+		return nil
+	})
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, readyErr)
+	assert.EqualError(t, err, "ready callback: notify failed")
+	assert.Equal(t, []string{"api"}, recorder.filter("stop:"))
+}
@@ -0,0 +1,553 @@
+package go_runner
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+type (
+	// Option настройка Runner, передаваемая в New
+	Option func(*Runner)
+
+	// AppOption настройка приложения, передаваемая при регистрации
+	AppOption func(*appStruct)
+
+	// CircuitBreaker настройки повторного запуска с автоматическим выключателем
+	CircuitBreaker struct {
+		// Threshold число неудачных попыток подряд, после которого выключатель размыкается
+		Threshold int
+		// RetryDelay пауза между попытками при замкнутом выключателе, по умолчанию 100 мс
+		RetryDelay time.Duration
+		// Cooldown время, в течение которого разомкнутый выключатель не пропускает
+		// попытки, по умолчанию RetryDelay
+		Cooldown time.Duration
+		// MaxAttempts максимальное число попыток, ноль — без ограничения в пределах
+		// времени запуска, а без WithMaxStartupTime — до отмены контекста Run
+		MaxAttempts int
+	}
+
+	// Policy политика остановки приложений
+	Policy struct {
+		// DrainDelay пауза между началом остановки и вызовом Stop приложений
+		DrainDelay time.Duration
+		// ShutdownTimeout максимальное время на остановку приложений, ноль — без ограничения
+		ShutdownTimeout time.Duration
+	}
+)
+
+// defaultRetryDelay пауза между попытками WithStartCircuitBreaker, если RetryDelay не задан
+const defaultRetryDelay = 100 * time.Millisecond
+
+// withBudget ограничивает политику бюджетом остановки: пауза перед остановкой и
+// Stop укладываются в budget. Пауза, занимающая весь бюджет, сокращается до его половины.
+func (p Policy) withBudget(budget time.Duration) Policy {
+	if p.DrainDelay >= budget {
+		p.DrainDelay = budget / 2
+	}
+
+	timeout := budget - p.DrainDelay
+	if p.ShutdownTimeout == 0 || p.ShutdownTimeout > timeout {
+		p.ShutdownTimeout = timeout
+	}
+
+	return p
+}
+
+// WithShutdownTimeout ограничивает время остановки приложений политики по умолчанию.
+func WithShutdownTimeout(d time.Duration) Option {
+	return func(r *Runner) {
+		r.policy.ShutdownTimeout = d
+	}
+}
+
+// WithAutoShutdownBudget резервирует на остановку долю fraction времени,
+// оставшегося до дедлайна контекста Run: остановка начинается заранее, а пауза
+// перед остановкой и Stop укладываются в зарезервированное время. Без дедлайна
+// у контекста опция не действует. fraction должна быть в интервале (0, 1).
+func WithAutoShutdownBudget(fraction float64) Option {
+	return func(r *Runner) {
+		if fraction > 0 && fraction < 1 {
+			r.budgetFraction = fraction
+		}
+	}
+}
+
+// WithHookTimeout ограничивает время вызова shutdown hooks. По истечении d Run
+// возвращает ErrShutdownTimeout, не дожидаясь зависшего hook. Ноль — используется
+// время остановки текущей политики.
+func WithHookTimeout(d time.Duration) Option {
+	return func(r *Runner) {
+		r.hookTimeout = d
+	}
+}
+
+// WithShutdownProgress вызывает fn с интервалом interval, пока приложения
+// останавливаются, передавая число приложений, завершивших Stop, и общее число
+// останавливаемых приложений. После остановки приложений fn больше не вызывается.
+func WithShutdownProgress(interval time.Duration, fn func(done, total int)) Option {
+	return func(r *Runner) {
+		r.progressInterval = interval
+		r.progress = fn
+	}
+}
+
+// WithDrainTimeout выделяет Drain приложений, реализующих Drainer, в отдельный
+// этап остановки: Drain всех останавливаемых приложений вызывается одновременно
+// до первого Stop, и этап длится не дольше d. По истечении d контекст Drain
+// отменяется с причиной ErrDrainTimeout, а остановка переходит к Stop, не
+// дожидаясь возврата из Drain. Ожидание возврата из Start приложений с
+// контекстом входит в этап. Время этапа не входит в WithShutdownTimeout,
+// поэтому долгий Drain не сокращает время, отведенное на Stop.
+func WithDrainTimeout(d time.Duration) Option {
+	return func(r *Runner) {
+		r.drainTimeout = d
+	}
+}
+
+// WithTerminationHandler передает fn итоговый результат Run после полной
+// остановки и сброса логгера — например, чтобы фреймворк, в который встроен
+// Runner, сам залогировал ошибку, отправил отчет или завершил процесс. С
+// обработчиком RunAndExit не завершает процесс.
+func WithTerminationHandler(fn func(err error)) Option {
+	return func(r *Runner) {
+		r.terminationHandler = fn
+	}
+}
+
+// WithMinUptime откладывает остановку по SIGTERM или SIGINT, полученному раньше
+// чем через d после начала Run, до истечения d, чтобы процесс, получающий сигнал
+// сразу после старта, не перезапускался в цикле. Повторный сигнал в этот период
+// отбрасывается и не прерывает ни ожидание, ни последующую остановку. Опция
+// задерживает реакцию на сигнал: если d больше времени, которое оркестратор дает
+// на остановку, процесс будет завершен принудительно без остановки приложений.
+// Ошибки запуска, отмена контекста Run и WithTriggerChannel не откладываются.
+func WithMinUptime(d time.Duration) Option {
+	return func(r *Runner) {
+		r.minUptime = d
+	}
+}
+
+// WithReadyTimeout ограничивает ожидание готовности приложения отдельно от
+// времени запуска: сигнала ready для RegisterReadyApp — с вызова start, и
+// завершения Warmup для приложений, реализующих Warmer, — с возврата из Start.
+// По истечении d запуск прерывается с ошибкой ErrReadyTimeout, дополненной именем
+// приложения, а Stop приложения вызывается при остановке, чтобы его завершить.
+func WithReadyTimeout(d time.Duration) Option {
+	return func(r *Runner) {
+		r.readyTimeout = d
+	}
+}
+
+// WithDrainDelay задает паузу перед остановкой приложений для политики по умолчанию.
+func WithDrainDelay(d time.Duration) Option {
+	return func(r *Runner) {
+		r.policy.DrainDelay = d
+	}
+}
+
+// WithSignalPolicy задает политику остановки, применяемую при получении указанного сигнала.
+// Для остальных сигналов и отмены контекста используется политика по умолчанию.
+func WithSignalPolicy(sig os.Signal, p Policy) Option {
+	return func(r *Runner) {
+		if r.signalPolicies == nil {
+			r.signalPolicies = make(map[os.Signal]Policy)
+		}
+		r.signalPolicies[sig] = p
+	}
+}
+
+// WithStartConcurrency ограничивает число одновременно выполняющихся Start.
+// Приложения запускаются в порядке регистрации, ноль снимает ограничение.
+// Приложение, чей Start не возвращается, занимает слот до остановки, поэтому
+// лимит должен учитывать приложения, от готовности которых зависят остальные.
+func WithStartConcurrency(n int) Option {
+	return func(r *Runner) {
+		r.startConcurrency = n
+	}
+}
+
+// WithSequentialStart запускает приложения по одному: следующее приложение
+// запускается после возврата из Start предыдущего, в порядке регистрации с учетом
+// приоритетов и зависимостей. Остановка выполняется в обратном порядке.
+func WithSequentialStart() Option {
+	return func(r *Runner) {
+		r.sequentialStart = true
+	}
+}
+
+// WithShutdownOnAppExit останавливает все приложения, если приложение с
+// блокирующим запуском (RegisterReadyApp) вернулось из start без ошибки до начала
+// остановки. Контекст Run отменяется с причиной ErrAppExited. Приложения, чей
+// Start возвращается после запуска, не затрагиваются.
+func WithShutdownOnAppExit() Option {
+	return func(r *Runner) {
+		r.shutdownOnAppExit = true
+	}
+}
+
+// WithHealthCheckInterval задает интервал проверок HealthChecker для приложений
+// с WithUnhealthyFatal. По умолчанию и для неположительного d — 10 секунд.
+func WithHealthCheckInterval(d time.Duration) Option {
+	return func(r *Runner) {
+		r.healthInterval = d
+	}
+}
+
+// WithFailOnStopError задает, возвращает ли Run ошибку Stop или shutdown hook при
+// остановке по сигналу. При true любая такая ошибка возвращается из Run, при
+// false (по умолчанию) она только логируется, а Run возвращает результат
+// остановки по сигналу. При остановке по другим причинам ошибки Stop
+// возвращаются всегда.
+func WithFailOnStopError(fail bool) Option {
+	return func(r *Runner) {
+		r.failOnStopError = fail
+	}
+}
+
+// WithContextDecorator дополняет контекст Run до запуска приложений, например
+// трассировщиком или значениями. Значения доступны в Start, Stop и shutdown
+// hooks. Дедлайн, добавленный fn, виден в Start через ctx.Deadline(); по его
+// истечении или отмене, добавленной fn, начинается остановка, а контекст Start
+// отменяется после паузы WithDrainDelay. Несколько декораторов применяются в
+// порядке передачи опций.
+func WithContextDecorator(fn func(ctx context.Context) context.Context) Option {
+	return func(r *Runner) {
+		if fn != nil {
+			r.decorators = append(r.decorators, fn)
+		}
+	}
+}
+
+// WithTolerateLateFailures оставляет остальные приложения работать, если
+// приложение с блокирующим запуском (RegisterReadyApp, RegisterActor) вернуло
+// ошибку после завершения запуска всех приложений: ошибка логируется, для
+// приложения вызывается Stop, а контекст Run не отменяется. Ошибки до
+// завершения запуска по-прежнему останавливают все приложения.
+func WithTolerateLateFailures() Option {
+	return func(r *Runner) {
+		r.tolerateLateFailures = true
+	}
+}
+
+// WithMaxStartupTime ограничивает время запуска всех приложений. Если к исходу d
+// не все приложения запущены, Run останавливает уже запущенные и возвращает
+// ErrStartupTimeout.
+func WithMaxStartupTime(d time.Duration) Option {
+	return func(r *Runner) {
+		r.maxStartupTime = d
+	}
+}
+
+// WithDryRun переводит Run в режим проверки: план запуска выводится в лог,
+// приложения не запускаются.
+func WithDryRun() Option {
+	return func(r *Runner) {
+		r.dryRun = true
+	}
+}
+
+// WithTriggerChannel запускает остановку при получении значения из ch или его
+// закрытии — так же, как при получении сигнала. Срабатывает то, что произойдет раньше.
+func WithTriggerChannel(ch <-chan struct{}) Option {
+	return func(r *Runner) {
+		r.trigger = ch
+	}
+}
+
+// WithSignalMode задает результат Run при штатной остановке по сигналу: nil
+// (SignalAsNil, по умолчанию), *SignalError (SignalAsError) или
+// *ExitCodeError с кодом 128+номер сигнала (SignalAsExitCode). При остановке по
+// сигналу ошибки запуска только логируются, ошибки Stop и shutdown hooks
+// возвращаются лишь с WithFailOnStopError(true), а ErrShutdownTimeout и
+// ErrForcedShutdown — всегда, вместо результата режима.
+func WithSignalMode(m SignalMode) Option {
+	return func(r *Runner) {
+		r.signalMode = m
+	}
+}
+
+// WithoutSignalHandling отключает обработку сигналов для встраивания Runner
+// в процесс, который сам обрабатывает сигналы. Остановка выполняется по отмене
+// контекста Run или через WithTriggerChannel.
+func WithoutSignalHandling() Option {
+	return func(r *Runner) {
+		r.withoutSignals = true
+	}
+}
+
+// WithGoroutineTagging добавляет в логи жизненного цикла поле seq — стабильный
+// номер регистрации приложения, по которому можно проследить его Start и Stop
+// в параллельном выводе.
+func WithGoroutineTagging() Option {
+	return func(r *Runner) {
+		r.goroutineTagging = true
+	}
+}
+
+// WithParallelStop останавливает приложения одного уровня параллельно, не более
+// n одновременно; ноль снимает ограничение. Уровни определяются зависимостями
+// и приоритетами: приложение останавливается только после всех зависящих от него,
+// а независимые ветви — одновременно. Без зависимостей и приоритетов все
+// приложения образуют один уровень, и n ограничивает остановку глобально;
+// Stop вызываются в порядке, обратном запуску.
+func WithParallelStop(n int) Option {
+	return func(r *Runner) {
+		r.parallelStop = true
+		r.stopConcurrency = n
+	}
+}
+
+// WithStopOrdering задает порядок остановки приложений: StopReverse (по
+// умолчанию) — обратный запуску, StopForward — в порядке запуска, StopPriority —
+// по приоритету WithStopOrder.
+func WithStopOrdering(ordering StopOrdering) Option {
+	return func(r *Runner) {
+		r.stopOrdering = ordering
+	}
+}
+
+// WithPanicHandler задает обработчик паник, восстановленных в Start, Stop и
+// shutdown hooks. Обработчик получает имя приложения, значение паники и стек
+// и вызывается до преобразования паники в ошибку ErrPanic. По умолчанию паника
+// логируется через Logger.
+func WithPanicHandler(h func(appName string, recovered any, stack []byte)) Option {
+	return func(r *Runner) {
+		r.panicHandler = h
+	}
+}
+
+// WithRecover включает или отключает восстановление паник в Start, Stop и
+// shutdown hooks. По умолчанию паника превращается в ошибку ErrPanic; при
+// enabled == false она не перехватывается и завершает процесс с исходным стеком,
+// что удобно при локальной отладке.
+func WithRecover(enabled bool) Option {
+	return func(r *Runner) {
+		r.noRecover = !enabled
+	}
+}
+
+// WithStackDumpOnTimeout записывает в w стеки всех горутин, когда остановка
+// приложений или shutdown hooks прерывается с ErrShutdownTimeout, чтобы найти
+// зависший Stop. При w == nil стеки пишутся в os.Stderr.
+func WithStackDumpOnTimeout(w io.Writer) Option {
+	if w == nil {
+		w = os.Stderr
+	}
+
+	return func(r *Runner) {
+		r.stackDump = w
+	}
+}
+
+// WithContextCancelCauseLogging добавляет в сообщение о начале остановки поле
+// cause с причиной отмены контекста Run: сигналом, ошибкой запуска с именем
+// приложения или причиной отмены родительского контекста.
+func WithContextCancelCauseLogging() Option {
+	return func(r *Runner) {
+		r.logCancelCause = true
+	}
+}
+
+// WithErrorClassifier задает классификатор ошибок Start и Stop приложений,
+// например, чтобы не считать ошибкой http.ErrServerClosed. Ошибка с
+// SeverityIgnore считается успехом, с SeverityWarn — логируется как
+// предупреждение и тоже считается успехом, с SeverityFatal — обрабатывается как
+// обычно.
+func WithErrorClassifier(classify func(err error) Severity) Option {
+	return func(r *Runner) {
+		r.classifier = classify
+	}
+}
+
+// WithReExecOnReload перезапускает процесс по SIGHUP: Runner останавливает все
+// приложения и заменяет процесс тем же исполняемым файлом с теми же аргументами
+// и окружением через syscall.Exec. SIGHUP до завершения запуска игнорируется, а
+// при ошибках остановки процесс не перезапускается, и Run завершается как при
+// SIGTERM, поэтому сбой запуска или остановки не приводит к бесконечным перезапускам.
+func WithReExecOnReload() Option {
+	return func(r *Runner) {
+		r.reExecOnReload = true
+	}
+}
+
+// WithVerbosity задает подробность сообщений Runner без замены логгера:
+// VerbosityQuiet оставляет только ошибки и итоговую строку о завершении,
+// VerbosityVerbose выводит отладочные сообщения о запуске и остановке как Info.
+func WithVerbosity(v Verbosity) Option {
+	return func(r *Runner) {
+		r.verbosity = v
+	}
+}
+
+// WithMessages заменяет сообщения и ключи полей, которые Runner передает в Logger,
+// например для локализации или соответствия схеме логов. Незаполненные поля
+// сохраняют значения по умолчанию.
+func WithMessages(m Messages) Option {
+	return func(r *Runner) {
+		r.messages = m.withDefaults()
+	}
+}
+
+// WithUnhealthyFatal начинает остановку всех приложений, если приложение,
+// реализующее HealthChecker, не прошло threshold проверок подряд после запуска.
+// Контекст Run отменяется с причиной ErrUnhealthy, и Run возвращает ошибку, чтобы
+// оркестратор перезапустил процесс. Интервал проверок задает WithHealthCheckInterval.
+func WithUnhealthyFatal(threshold int) AppOption {
+	return func(a *appStruct) {
+		a.UnhealthyThreshold = threshold
+	}
+}
+
+// WithStopOnStartError вызывает Stop приложения при остановке, даже если его Start
+// вернул ошибку. Stop такого приложения должен корректно обрабатывать частичную
+// инициализацию.
+func WithStopOnStartError() AppOption {
+	return func(a *appStruct) {
+		a.StopOnStartError = true
+	}
+}
+
+// WithPriority задает приоритет приложения. Приложения с большим приоритетом
+// запускаются раньше: следующий уровень приоритета запускается после того, как
+// запущены все приложения предыдущего. Остановка выполняется в обратном порядке.
+// При равном приоритете сохраняется порядок регистрации.
+func WithPriority(p int) AppOption {
+	return func(a *appStruct) {
+		a.Priority = p
+	}
+}
+
+// DependsOn объявляет зависимости приложения от других приложений по имени.
+// Приложение запускается после своих зависимостей и останавливается раньше них.
+func DependsOn(names ...string) AppOption {
+	return func(a *appStruct) {
+		a.DependsOn = append(a.DependsOn, names...)
+	}
+}
+
+// WithStartCircuitBreaker повторяет неудачный Start приложения. После cfg.Threshold
+// неудач подряд выключатель размыкается: попытки не выполняются в течение
+// cfg.Cooldown, затем выполняется одна пробная. Попытки ограничены временем запуска
+// (WithMaxStartupTime) и cfg.MaxAttempts; ошибкой запуска становится ошибка
+// последней попытки. Нулевой RetryDelay заменяется на defaultRetryDelay, нулевой
+// Cooldown — на RetryDelay. При нулевом MaxAttempts и без WithMaxStartupTime
+// неудачный Start повторяется, пока не будет отменен контекст Run.
+func WithStartCircuitBreaker(cfg CircuitBreaker) AppOption {
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = defaultRetryDelay
+	}
+	if cfg.Cooldown <= 0 {
+		cfg.Cooldown = cfg.RetryDelay
+	}
+
+	return func(a *appStruct) {
+		a.StartBreaker = &cfg
+	}
+}
+
+// WithFailureDomain включает приложение в домен отказа name. Ошибка запуска
+// приложения домена останавливает в обратном порядке только приложения этого
+// домена, остальные продолжают работу. Run завершается, когда остановлены все
+// домены и нет приложений вне доменов, либо при ошибке приложения вне доменов или
+// с WithFatal. Ошибка домена возвращается из Run по его завершении.
+func WithFailureDomain(name string) AppOption {
+	return func(a *appStruct) {
+		a.Domain = name
+	}
+}
+
+// WithFatal делает ошибку запуска приложения фатальной для всего Run, даже если
+// приложение входит в домен отказа.
+func WithFatal() AppOption {
+	return func(a *appStruct) {
+		a.Fatal = true
+	}
+}
+
+// WithOptional делает приложение необязательным, например экспортер метрик или
+// профилировщик: ошибка его запуска логируется как предупреждение, а остальные
+// приложения запускаются и работают. Не запустившееся приложение не
+// останавливается. Зависимые от него приложения запускаются как обычно.
+func WithOptional() AppOption {
+	return func(a *appStruct) {
+		a.Optional = true
+	}
+}
+
+// WithRestartOnReload перезапускает приложение при получении SIGHUP: оно
+// останавливается и запускается заново с учетом порядка зависимостей, остальные
+// приложения продолжают работу. Остановленное через StopApp или не запустившееся
+// приложение не перезапускается.
+func WithRestartOnReload() AppOption {
+	return func(a *appStruct) {
+		a.RestartOnReload = true
+	}
+}
+
+// WithStopOrder задает приоритет остановки приложения независимо от порядка
+// запуска: приложения с большим приоритетом останавливаются раньше. Если опция
+// задана хотя бы одному приложению, остальные имеют приоритет остановки 0, а без
+// WithStopOrdering применяется порядок StopPriority, на который зависимости и
+// WithPriority не влияют.
+func WithStopOrder(priority int) AppOption {
+	return func(a *appStruct) {
+		a.StopPriority = priority
+		a.StopOrdered = true
+	}
+}
+
+// WithStopFirst останавливает приложение раньше всех остальных независимо от
+// порядка регистрации, зависимостей и приоритетов. Приложения WithStopFirst
+// останавливаются между собой в обычном порядке.
+func WithStopFirst() AppOption {
+	return func(a *appStruct) {
+		a.StopFirst = true
+		a.StopLast = false
+	}
+}
+
+// WithStopLast останавливает приложение после всех остальных независимо от
+// порядка регистрации, зависимостей и приоритетов — например, отправку
+// телеметрии или логгер. Приложения WithStopLast останавливаются между собой в
+// обычном порядке.
+func WithStopLast() AppOption {
+	return func(a *appStruct) {
+		a.StopLast = true
+		a.StopFirst = false
+	}
+}
+
+// WithStopBefore требует остановить приложение раньше приложений names.
+// Ограничения дополняют порядок, обратный запуску; противоречивые ограничения
+// приводят к ошибке ErrDependencyCycle до запуска, а сочетание с другим порядком
+// WithStopOrdering или WithStopOrder — к ErrStopConstraint.
+func WithStopBefore(names ...string) AppOption {
+	return func(a *appStruct) {
+		a.StopBefore = append(a.StopBefore, names...)
+	}
+}
+
+// WithStopAfter требует остановить приложение после приложений names. Ограничения
+// проверяются так же, как у WithStopBefore.
+func WithStopAfter(names ...string) AppOption {
+	return func(a *appStruct) {
+		a.StopAfter = append(a.StopAfter, names...)
+	}
+}
+
+// WithName задает имя приложения вместо переданного при регистрации.
+func WithName(name string) AppOption {
+	return func(a *appStruct) {
+		a.Name = name
+	}
+}
+
+// WithEnabled при enabled == false отменяет регистрацию приложения, позволяя
+// включать и отключать его из конфигурации без условий вокруг Register.
+func WithEnabled(enabled bool) AppOption {
+	return func(a *appStruct) {
+		a.Disabled = !enabled
+	}
+}
@@ -0,0 +1,326 @@
+package go_runner
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Порядок остановки приложений. Нулевое значение StopOrdering означает, что
+// порядок не задан: применяется StopReverse, а при WithStopOrder — StopPriority.
+const (
+	// StopReverse останавливает приложения в порядке, обратном запуску, с учетом
+	// зависимостей и приоритетов
+	StopReverse StopOrdering = iota + 1
+	// StopForward останавливает приложения в порядке запуска
+	StopForward
+	// StopPriority останавливает приложения по приоритету WithStopOrder
+	StopPriority
+	// StopReverseReady останавливает приложения по одному в порядке, обратном
+	// фактическому завершению их запуска. Plan показывает порядок StopReverse.
+	StopReverseReady
+)
+
+// StopOrdering порядок остановки приложений
+type StopOrdering int
+
+// validate проверяет конфигурацию целиком и возвращает волны запуска и уровни
+// остановки. Run вызывает ее до запуска первого приложения, поэтому ошибки
+// зависимостей, имен и ограничений порядка остановки не приводят к частичному
+// запуску.
+func (r *Runner) validate() (waves, levels [][]int, err error) {
+	waves, err = r.resolve()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	levels, err = r.shutdownLevels(waves)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return waves, levels, nil
+}
+
+// resolve вычисляет волны запуска. Приложение попадает в волну после всех
+// приложений, от которых оно зависит, и всех приложений с большим приоритетом.
+// Приложения одной волны запускаются параллельно, при равных условиях сохраняется
+// порядок регистрации. Возвращает ошибку для неизвестных зависимостей,
+// повторяющихся имен и циклов.
+func (r *Runner) resolve() ([][]int, error) {
+	byName := make(map[string]int, len(r.apps))
+	for i, a := range r.apps {
+		if a.Start == nil || a.Name == "" {
+			continue
+		}
+		if _, ok := byName[a.Name]; ok {
+			return nil, fmt.Errorf("%w: %q", ErrDuplicateName, a.Name)
+		}
+		byName[a.Name] = i
+	}
+
+	// Предшественники каждого приложения: зависимости и приложения с большим приоритетом
+	preds := make(map[int][]int)
+	var nodes []int
+	for i, a := range r.apps {
+		if a.Start == nil {
+			continue
+		}
+		nodes = append(nodes, i)
+
+		for _, dep := range a.DependsOn {
+			j, ok := byName[dep]
+			if !ok {
+				return nil, fmt.Errorf("%w: %s depends on %q", ErrUnknownDependency, r.label(i), dep)
+			}
+			preds[i] = append(preds[i], j)
+		}
+
+		for j, b := range r.apps {
+			if b.Start != nil && b.Priority > a.Priority {
+				preds[i] = append(preds[i], j)
+			}
+		}
+	}
+
+	return r.layers(nodes, preds)
+}
+
+// layers выполняет послойную топологическую сортировку: узел попадает в слой
+// после всех своих предшественников, внутри слоя сохраняется порядок nodes.
+// Возвращает ErrDependencyCycle, если узлы образуют цикл.
+func (r *Runner) layers(nodes []int, preds map[int][]int) ([][]int, error) {
+	var layers [][]int
+	placed := make(map[int]bool, len(nodes))
+	for len(placed) < len(nodes) {
+		var layer []int
+		for _, i := range nodes {
+			if placed[i] {
+				continue
+			}
+			if !slices.ContainsFunc(preds[i], func(j int) bool { return !placed[j] }) {
+				layer = append(layer, i)
+			}
+		}
+
+		if len(layer) == 0 {
+			var cycle []string
+			for _, i := range nodes {
+				if !placed[i] {
+					cycle = append(cycle, r.label(i))
+				}
+			}
+			return nil, fmt.Errorf("%w: %s", ErrDependencyCycle, strings.Join(cycle, ", "))
+		}
+
+		for _, i := range layer {
+			placed[i] = true
+		}
+		layers = append(layers, layer)
+	}
+
+	return layers, nil
+}
+
+// stopLevels возвращает уровни остановки — волны запуска в обратном порядке.
+// Приложение оказывается на уровне раньше всех своих зависимостей, поэтому
+// зависимые приложения останавливаются до тех, от которых зависят.
+func stopLevels(waves [][]int) [][]int {
+	levels := make([][]int, 0, len(waves))
+	for i := len(waves) - 1; i >= 0; i-- {
+		level := slices.Clone(waves[i])
+		slices.Reverse(level)
+		levels = append(levels, level)
+	}
+
+	return levels
+}
+
+// shutdownLevels возвращает уровни остановки в порядке WithStopOrdering, в
+// начало и конец которого перенесены приложения WithStopFirst и WithStopLast. Если
+// порядок не задан, а хотя бы одно приложение задает WithStopOrder, применяется
+// StopPriority: приложения с большим значением останавливаются раньше, с равным —
+// на одном уровне в порядке, обратном запуску. В порядке StopReverse учитываются
+// WithStopBefore и WithStopAfter, с другими порядками они приводят к
+// ErrStopConstraint.
+func (r *Runner) shutdownLevels(waves [][]int) ([][]int, error) {
+	levels, err := r.orderedLevels(waves)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.pinLevels(levels), nil
+}
+
+// orderedLevels возвращает уровни остановки в порядке WithStopOrdering без учета
+// WithStopFirst и WithStopLast. Возвращает ErrStopConstraint, если порядок не
+// учитывает WithStopBefore и WithStopAfter.
+func (r *Runner) orderedLevels(waves [][]int) ([][]int, error) {
+	ordering := r.stopOrdering
+	if ordering == 0 && slices.ContainsFunc(r.apps, func(a appStruct) bool { return a.StopOrdered }) {
+		ordering = StopPriority
+	}
+
+	// WithStopBefore и WithStopAfter дополняют только порядок, обратный запуску
+	constrained := slices.IndexFunc(r.apps, func(a appStruct) bool {
+		return a.Start != nil && (len(a.StopBefore) > 0 || len(a.StopAfter) > 0)
+	})
+	if constrained >= 0 && ordering != 0 && ordering != StopReverse {
+		return nil, fmt.Errorf("%w: %s", ErrStopConstraint, r.label(constrained))
+	}
+
+	switch ordering {
+	case StopForward:
+		levels := make([][]int, 0, len(waves))
+		for _, wave := range waves {
+			levels = append(levels, slices.Clone(wave))
+		}
+		return levels, nil
+	case StopPriority:
+		return r.priorityLevels(waves), nil
+	default:
+		if constrained >= 0 {
+			return r.constrainedLevels(waves)
+		}
+		return stopLevels(waves), nil
+	}
+}
+
+// pinLevels переносит приложения WithStopFirst в начало остановки, а
+// WithStopLast — в конец. Внутри каждой группы сохраняются уровни и порядок levels.
+func (r *Runner) pinLevels(levels [][]int) [][]int {
+	pinned := func(first, last bool) [][]int {
+		var result [][]int
+		for _, level := range levels {
+			level = slices.DeleteFunc(slices.Clone(level), func(i int) bool {
+				return r.apps[i].StopFirst != first || r.apps[i].StopLast != last
+			})
+			if len(level) > 0 {
+				result = append(result, level)
+			}
+		}
+		return result
+	}
+
+	return slices.Concat(pinned(true, false), pinned(false, false), pinned(false, true))
+}
+
+// reverseReadyLevels возвращает уровни остановки для StopReverseReady: по одному
+// приложению в порядке, обратном завершению запуска в текущем Run. Приложения,
+// не сообщившие о запуске, останавливаются последними в порядке levels.
+func (r *Runner) reverseReadyLevels(levels [][]int) [][]int {
+	r.mu.Lock()
+	order := slices.Clone(r.readyOrder)
+	r.mu.Unlock()
+
+	// После перезапуска по SIGHUP учитывается последнее завершение запуска
+	seen := make(map[int]bool, len(order))
+	result := make([][]int, 0, len(order))
+	for k := len(order) - 1; k >= 0; k-- {
+		if i := order[k]; !seen[i] {
+			seen[i] = true
+			result = append(result, []int{i})
+		}
+	}
+
+	for _, level := range levels {
+		rest := slices.DeleteFunc(slices.Clone(level), func(i int) bool { return seen[i] })
+		if len(rest) > 0 {
+			result = append(result, rest)
+		}
+	}
+
+	return result
+}
+
+// constrainedLevels вычисляет уровни остановки с учетом WithStopBefore и
+// WithStopAfter: приложение останавливается после всех, кто от него зависит,
+// приложений с меньшим приоритетом и приложений из ограничений. На одном уровне
+// сохраняется порядок, обратный запуску. Возвращает ErrUnknownDependency для
+// неизвестных имен и ErrDependencyCycle для противоречивых ограничений.
+func (r *Runner) constrainedLevels(waves [][]int) ([][]int, error) {
+	nodes := stopOrder(waves)
+	byName := make(map[string]int, len(nodes))
+	for _, i := range nodes {
+		if r.apps[i].Name != "" {
+			byName[r.apps[i].Name] = i
+		}
+	}
+
+	lookup := func(i int, name string) (int, error) {
+		j, ok := byName[name]
+		if !ok {
+			return 0, fmt.Errorf("%w: %s stop constraint %q", ErrUnknownDependency, r.label(i), name)
+		}
+		return j, nil
+	}
+
+	// Предшественники каждого приложения — те, кто должен остановиться раньше него
+	preds := make(map[int][]int)
+	for _, i := range nodes {
+		a := r.apps[i]
+		for _, dep := range a.DependsOn {
+			j := byName[dep]
+			preds[j] = append(preds[j], i)
+		}
+
+		for _, j := range nodes {
+			if r.apps[j].Priority < a.Priority {
+				preds[i] = append(preds[i], j)
+			}
+		}
+
+		for _, name := range a.StopBefore {
+			j, err := lookup(i, name)
+			if err != nil {
+				return nil, err
+			}
+			preds[j] = append(preds[j], i)
+		}
+
+		for _, name := range a.StopAfter {
+			j, err := lookup(i, name)
+			if err != nil {
+				return nil, err
+			}
+			preds[i] = append(preds[i], j)
+		}
+	}
+
+	return r.layers(nodes, preds)
+}
+
+// priorityLevels группирует приложения в уровни остановки по убыванию приоритета
+// WithStopOrder. Приложения с равным приоритетом сохраняют порядок, обратный запуску.
+func (r *Runner) priorityLevels(waves [][]int) [][]int {
+	order := stopOrder(waves)
+	slices.SortStableFunc(order, func(i, j int) int {
+		return cmp.Compare(r.apps[j].StopPriority, r.apps[i].StopPriority)
+	})
+
+	var levels [][]int
+	for k, i := range order {
+		if k > 0 && r.apps[i].StopPriority == r.apps[order[k-1]].StopPriority {
+			levels[len(levels)-1] = append(levels[len(levels)-1], i)
+			continue
+		}
+		levels = append(levels, []int{i})
+	}
+
+	return levels
+}
+
+// stopOrder возвращает индексы приложений в порядке остановки — обратном порядку запуска.
+func stopOrder(waves [][]int) []int {
+	return slices.Concat(stopLevels(waves)...)
+}
+
+// label возвращает имя приложения, а для безымянного — его номер регистрации.
+func (r *Runner) label(i int) string {
+	if r.apps[i].Name != "" {
+		return r.apps[i].Name
+	}
+
+	return fmt.Sprintf("#%d", i)
+}
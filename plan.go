@@ -0,0 +1,52 @@
+package go_runner
+
+import "slices"
+
+// Plan план запуска и остановки приложений
+type Plan struct {
+	// Start волны запуска: приложения одной волны запускаются параллельно
+	Start [][]string `json:"start"`
+	// Stop порядок остановки приложений
+	Stop []string `json:"stop"`
+	// DependsOn зависимости приложений
+	DependsOn map[string][]string `json:"depends_on,omitempty"`
+}
+
+// Plan вычисляет порядок запуска и остановки без запуска приложений. Ошибки
+// конфигурации (неизвестные зависимости, повторяющиеся имена, циклы, в том числе
+// в ограничениях порядка остановки)
+// возвращаются до запуска Run.
+func (r *Runner) Plan() (Plan, error) {
+	waves, levels, err := r.validate()
+	if err != nil {
+		return Plan{}, err
+	}
+
+	p := Plan{
+		Start: make([][]string, 0, len(waves)),
+	}
+
+	for _, wave := range waves {
+		names := make([]string, 0, len(wave))
+		for _, i := range wave {
+			names = append(names, r.label(i))
+		}
+		p.Start = append(p.Start, names)
+	}
+
+	for _, i := range slices.Concat(levels...) {
+		p.Stop = append(p.Stop, r.label(i))
+	}
+
+	for i, a := range r.apps {
+		if a.Start == nil || len(a.DependsOn) == 0 {
+			continue
+		}
+		if p.DependsOn == nil {
+			p.DependsOn = make(map[string][]string)
+		}
+		p.DependsOn[r.label(i)] = a.DependsOn
+	}
+
+	return p, nil
+}
@@ -0,0 +1,142 @@
+package go_runner
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_Plan(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("worker", &MockApp{}, DependsOn("api"))
+	runner.RegisterNamedApp("api", &MockApp{}, DependsOn("db", "cache"))
+	runner.RegisterNamedApp("db", &MockApp{})
+	runner.RegisterNamedApp("cache", &MockApp{})
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+
+	assert.Equal(t, [][]string{{"db", "cache"}, {"api"}, {"worker"}}, plan.Start)
+	assert.Equal(t, []string{"worker", "api", "cache", "db"}, plan.Stop)
+	assert.Equal(t, map[string][]string{
+		"worker": {"api"},
+		"api":    {"db", "cache"},
+	}, plan.DependsOn)
+}
+
+func TestAppsRunner_Plan_Cycle(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, DependsOn("b"))
+	runner.RegisterNamedApp("b", &MockApp{}, DependsOn("a"))
+	runner.RegisterNamedApp("c", &MockApp{}, DependsOn("missing"))
+
+	_, err := runner.Plan()
+	require.ErrorIs(t, err, ErrUnknownDependency)
+
+	runner = New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, DependsOn("b"))
+	runner.RegisterNamedApp("b", &MockApp{}, DependsOn("a"))
+	runner.RegisterNamedApp("c", &MockApp{})
+
+	_, err = runner.Plan()
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "a, b")
+
+	// Start не должен вызываться: мок без ожиданий упадет при вызове
+	err = runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrDependencyCycle)
+}
+
+func TestAppsRunner_Run_DryRun(t *testing.T) {
+	loggerMock := &MockLogger{}
+	loggerMock.On("Info", "dry run", "start", [][]string{{"db"}, {"api"}}, "stop", []string{"api", "db"}).Once()
+
+	runner := New(loggerMock, WithDryRun())
+	runner.RegisterNamedApp("api", &MockApp{}, DependsOn("db"))
+	runner.RegisterNamedApp("db", &MockApp{})
+
+	require.NoError(t, runner.Run(context.Background()))
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Plan_StopConstraints(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, WithStopBefore("d"))
+	runner.RegisterNamedApp("b", &MockApp{})
+	runner.RegisterNamedApp("c", &MockApp{}, WithStopAfter("b"))
+	runner.RegisterNamedApp("d", &MockApp{})
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"a", "b", "c", "d"}}, plan.Start)
+	assert.Equal(t, []string{"b", "a", "d", "c"}, plan.Stop)
+}
+
+func TestAppsRunner_Plan_StopConstraintsCycle(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, WithStopBefore("b"))
+	runner.RegisterNamedApp("b", &MockApp{}, WithStopBefore("a"))
+
+	_, err := runner.Plan()
+	require.ErrorIs(t, err, ErrDependencyCycle)
+
+	// Ограничение противоречит зависимости: api зависит от db и останавливается раньше
+	runner = New(discardLogger{})
+	runner.RegisterNamedApp("db", &MockApp{}, WithStopBefore("api"))
+	runner.RegisterNamedApp("api", &MockApp{}, DependsOn("db"))
+
+	require.ErrorIs(t, runner.Run(context.Background()), ErrDependencyCycle)
+
+	runner = New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, WithStopAfter("missing"))
+
+	_, err = runner.Plan()
+	require.ErrorIs(t, err, ErrUnknownDependency)
+}
+
+func TestAppsRunner_Plan_StopConstraintsOrdering(t *testing.T) {
+	for _, opt := range []Option{
+		WithStopOrdering(StopForward),
+		WithStopOrdering(StopPriority),
+		WithStopOrdering(StopReverseReady),
+	} {
+		runner := New(discardLogger{}, opt)
+		runner.RegisterNamedApp("a", &MockApp{})
+		runner.RegisterNamedApp("b", &MockApp{}, WithStopBefore("nope"))
+
+		_, err := runner.Plan()
+		require.ErrorIs(t, err, ErrStopConstraint)
+		assert.Contains(t, err.Error(), "b")
+	}
+
+	// WithStopOrder включает StopPriority, который не учитывает ограничения
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("a", &MockApp{}, WithStopOrder(1))
+	runner.RegisterNamedApp("b", &MockApp{}, WithStopAfter("a"))
+
+	require.ErrorIs(t, runner.Run(context.Background()), ErrStopConstraint)
+
+	runner = New(discardLogger{}, WithStopOrdering(StopReverse))
+	runner.RegisterNamedApp("a", &MockApp{})
+	runner.RegisterNamedApp("b", &MockApp{}, WithStopBefore("a"))
+
+	_, err := runner.Plan()
+	require.NoError(t, err)
+}
+
+func TestAppsRunner_Run_ValidatesBeforeStart(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	// Независимое приложение регистрируется первым и могло бы запуститься до проверки цикла
+	runner.RegisterNamedApp("first", &recordingApp{name: "first", recorder: recorder})
+	runner.RegisterNamedApp("a", &recordingApp{name: "a", recorder: recorder}, DependsOn("b"))
+	runner.RegisterNamedApp("b", &recordingApp{name: "b", recorder: recorder}, DependsOn("a"))
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrDependencyCycle)
+	assert.Contains(t, err.Error(), "a, b")
+	assert.Empty(t, recorder.Calls())
+}
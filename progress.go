@@ -0,0 +1,44 @@
+package go_runner
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// stopProgress счетчики остановки приложений для WithShutdownProgress
+type stopProgress struct {
+	done  atomic.Int64
+	total atomic.Int64
+}
+
+// trackProgress вызывает обработчик WithShutdownProgress с заданным интервалом,
+// пока не будет вызвана возвращенная функция. После ее возврата обработчик
+// больше не вызывается.
+func (r *Runner) trackProgress(p *stopProgress) func() {
+	if r.progressInterval <= 0 || r.progress == nil {
+		return func() {}
+	}
+
+	quit := make(chan struct{})
+	finished := make(chan struct{})
+	go func() {
+		defer close(finished)
+
+		ticker := time.NewTicker(r.progressInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				r.progress(int(p.done.Load()), int(p.total.Load()))
+			case <-quit:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(quit)
+		<-finished
+	}
+}
@@ -0,0 +1,56 @@
+package go_runner
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_Run_WithShutdownProgress(t *testing.T) {
+	recorder := &callRecorder{}
+
+	var (
+		mu     sync.Mutex
+		dones  []int
+		totals []int
+	)
+	progress := func(done, total int) {
+		mu.Lock()
+		defer mu.Unlock()
+		dones = append(dones, done)
+		totals = append(totals, total)
+	}
+
+	runner := New(discardLogger{}, WithShutdownProgress(5*time.Millisecond, progress))
+	for _, name := range []string{"a", "b", "c"} {
+		runner.RegisterNamedApp(name, &recordingApp{name: name, recorder: recorder, stopDelay: 30 * time.Millisecond})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	mu.Lock()
+	calls := len(dones)
+	require.NotEmpty(t, dones)
+	// Число остановленных приложений растет по мере остановки
+	assert.True(t, slices.IsSorted(dones))
+	assert.Greater(t, dones[len(dones)-1], dones[0])
+	assert.Equal(t, 3, totals[len(totals)-1])
+	mu.Unlock()
+
+	// После остановки обработчик больше не вызывается
+	time.Sleep(20 * time.Millisecond)
+	mu.Lock()
+	assert.Len(t, dones, calls)
+	mu.Unlock()
+}
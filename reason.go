@@ -0,0 +1,62 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+)
+
+// Причины остановки
+const (
+	// ReasonClean остановка по отмене контекста Run или WithTriggerChannel
+	ReasonClean Reason = "clean"
+	// ReasonSignal остановка по сигналу
+	ReasonSignal Reason = "signal"
+	// ReasonError остановка из-за ошибки приложения
+	ReasonError Reason = "error"
+	// ReasonTimeout превышено время запуска или остановки
+	ReasonTimeout Reason = "timeout"
+)
+
+type (
+	// Reason причина остановки
+	Reason string
+
+	// reasonKey ключ причины остановки в контексте Stop и shutdown hooks
+	reasonKey struct{}
+)
+
+// ShutdownReason возвращает причину остановки из контекста, переданного в Stop
+// или shutdown hook, и пустую строку для других контекстов. В Stop приложений
+// причина отражает начало остановки, в hooks — ее итог, включая превышение времени.
+func ShutdownReason(ctx context.Context) Reason {
+	reason, _ := ctx.Value(reasonKey{}).(Reason)
+	return reason
+}
+
+// withReason добавляет в контекст текущую причину остановки.
+func (r *Runner) withReason(ctx context.Context) context.Context {
+	return context.WithValue(ctx, reasonKey{}, r.shutdownReason())
+}
+
+// shutdownReason вычисляет причину остановки по ошибкам и полученному сигналу.
+func (r *Runner) shutdownReason() Reason {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	reason := ReasonClean
+	if r.signal != nil {
+		reason = ReasonSignal
+	}
+
+	for _, f := range r.failures {
+		switch {
+		case errors.Is(f.Err, ErrShutdownTimeout), errors.Is(f.Err, ErrStartupTimeout):
+			return ReasonTimeout
+		case errors.Is(f.Err, ErrForcedShutdown):
+		default:
+			reason = ReasonError
+		}
+	}
+
+	return reason
+}
@@ -0,0 +1,87 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShutdownReason(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		startErr error
+		want     Reason
+	}{
+		{name: "clean", want: ReasonClean},
+		{name: "error", startErr: errors.New("start error"), want: ReasonError},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := New(discardLogger{})
+			runner.RegisterStartOnly("api", func() error { return tt.startErr })
+
+			var reason Reason
+			runner.RegisterContextShutdownHook(func(ctx context.Context) error {
+				reason = ShutdownReason(ctx)
+				return nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				assert.Eventually(t, func() bool { return runner.State() != StateStarting }, time.Second, time.Millisecond)
+				cancel()
+			}()
+
+			err := runner.Run(ctx)
+			if tt.startErr != nil {
+				require.ErrorIs(t, err, tt.startErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, reason)
+		})
+	}
+
+	assert.Empty(t, ShutdownReason(context.Background()))
+}
+
+func TestAppsRunner_RegisterShutdownHookWithError(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		startErr error
+	}{
+		{name: "clean"},
+		{name: "error", startErr: errors.New("start error")},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := New(discardLogger{})
+			runner.RegisterStartOnly("api", func() error { return tt.startErr })
+
+			var hookErr error
+			runner.RegisterShutdownHookWithError(func(_ context.Context, err error) error {
+				hookErr = err
+				return nil
+			})
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				assert.Eventually(t, func() bool { return runner.State() != StateStarting }, time.Second, time.Millisecond)
+				cancel()
+			}()
+
+			err := runner.Run(ctx)
+			if tt.startErr == nil {
+				require.NoError(t, err)
+				assert.NoError(t, hookErr)
+				return
+			}
+
+			// Hook получает ошибку запуска, вызвавшую остановку, в том виде, в котором ее возвращает Run
+			require.ErrorIs(t, hookErr, tt.startErr)
+			assert.Equal(t, err, hookErr)
+		})
+	}
+}
@@ -0,0 +1,57 @@
+package go_runner
+
+import (
+	"fmt"
+	"os"
+)
+
+// requestReExec запоминает запрос перезапуска процесса. До завершения запуска
+// запрос игнорируется.
+func (r *Runner) requestReExec(startupDone <-chan struct{}) bool {
+	select {
+	case <-startupDone:
+	default:
+		r.logger.Warn(r.messages.ReloadIgnored)
+		return false
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.reExecRequested = true
+	return true
+}
+
+// reExecPending сообщает, что запрошен перезапуск процесса и остановка прошла
+// без ошибок.
+func (r *Runner) reExecPending() bool {
+	r.mu.Lock()
+	requested, clean := r.reExecRequested, len(r.failures) == 0
+	r.mu.Unlock()
+
+	if requested && !clean {
+		r.logger.Warn(r.messages.ReExecSkipped)
+	}
+
+	return requested && clean
+}
+
+// reExec заменяет процесс тем же исполняемым файлом с исходными аргументами и
+// окружением. При успехе управление не возвращается.
+func (r *Runner) reExec() error {
+	path, err := os.Executable()
+	if err != nil {
+		return fmt.Errorf("re-exec: %w", err)
+	}
+
+	r.logger.Info(r.messages.ReExecuting, r.messages.PathKey, path, r.messages.ArgsKey, os.Args)
+
+	// Процесс будет заменен, поэтому буфер логгера сбрасывается заранее
+	flushLogger(r.logger)
+
+	if err := r.exec(path, os.Args, os.Environ()); err != nil {
+		return fmt.Errorf("re-exec: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,73 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"time"
+)
+
+// Инициаторы остановки
+const (
+	// TriggerSignal остановка по сигналу
+	TriggerSignal Trigger = "signal"
+	// TriggerContext отмена контекста, переданного в Run
+	TriggerContext Trigger = "context"
+	// TriggerError ошибка запуска приложения или превышение времени запуска
+	TriggerError Trigger = "error"
+	// TriggerInternal WithTriggerChannel или завершение приложения при WithShutdownOnAppExit
+	TriggerInternal Trigger = "internal"
+)
+
+type (
+	// Trigger инициатор остановки
+	Trigger string
+
+	// Result итог запуска
+	Result struct {
+		// Trigger инициатор остановки, пусто, если приложения не запускались
+		Trigger Trigger
+		// Signal сигнал, по которому началась остановка, или nil
+		Signal os.Signal
+		// StartedAt время начала Run
+		StartedAt time.Time
+		// Duration общее время работы Run
+		Duration time.Duration
+	}
+)
+
+// RunResult запускает приложения так же, как Run, и дополнительно возвращает
+// инициатора остановки, сигнал и длительность запуска.
+func (r *Runner) RunResult(ctx context.Context) (Result, error) {
+	err := r.Run(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return Result{
+		Trigger:   r.shutdownTrigger(err),
+		Signal:    r.signal,
+		StartedAt: r.startedAt,
+		Duration:  r.duration,
+	}, err
+}
+
+// shutdownTrigger определяет инициатора остановки по причине отмены контекста Run.
+// Вызывается под r.mu.
+func (r *Runner) shutdownTrigger(err error) Trigger {
+	var sigErr *SignalError
+	switch {
+	case errors.As(r.cause, &sigErr):
+		return TriggerSignal
+	case errors.Is(r.cause, ErrTriggered), errors.Is(r.cause, ErrAppExited):
+		return TriggerInternal
+	case errors.Is(r.cause, ErrStartFailed), errors.Is(r.cause, ErrStartupTimeout), errors.Is(r.cause, ErrUnhealthy):
+		return TriggerError
+	case r.cause != nil, errors.Is(err, ErrContextAlreadyCancelled):
+		return TriggerContext
+	case err != nil:
+		return TriggerError
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,87 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_RunResult_Signal(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterStartStop("api", func() error { return nil }, func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+	signals := newFakeSignals(runner)
+
+	go func() {
+		<-runner.Ready()
+		signals.send(t, syscall.SIGTERM)
+	}()
+
+	startedAt := time.Now()
+	result, err := runner.RunResult(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, TriggerSignal, result.Trigger)
+	assert.Equal(t, syscall.SIGTERM, result.Signal)
+	assert.WithinDuration(t, startedAt, result.StartedAt, 10*time.Millisecond)
+	assert.GreaterOrEqual(t, result.Duration, 10*time.Millisecond)
+}
+
+func TestRunner_RunResult_Trigger(t *testing.T) {
+	startErr := errors.New("start error")
+
+	for _, tt := range []struct {
+		name  string
+		setup func(r *Runner, cancel context.CancelFunc)
+		want  Trigger
+	}{
+		{
+			name: "context",
+			setup: func(r *Runner, cancel context.CancelFunc) {
+				r.RegisterStartOnly("api", func() error {
+					cancel()
+					return nil
+				})
+			},
+			want: TriggerContext,
+		},
+		{
+			name: "error",
+			setup: func(r *Runner, _ context.CancelFunc) {
+				r.RegisterStartOnly("api", func() error { return startErr })
+			},
+			want: TriggerError,
+		},
+		{
+			name: "internal",
+			setup: func(r *Runner, _ context.CancelFunc) {
+				trigger := make(chan struct{})
+				WithTriggerChannel(trigger)(r)
+				r.RegisterStartOnly("api", func() error {
+					close(trigger)
+					return nil
+				})
+			},
+			want: TriggerInternal,
+		},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			runner := New(discardLogger{})
+			tt.setup(runner, cancel)
+
+			result, _ := runner.RunResult(ctx)
+			assert.Equal(t, tt.want, result.Trigger)
+			assert.Nil(t, result.Signal)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package go_runner
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Этапы жизненного цикла, на которых произошла ошибка
+const (
+	PhaseStart  Phase = "start"
+	PhaseStop   Phase = "stop"
+	PhaseHook   Phase = "hook"
+	PhaseHealth Phase = "health"
+)
+
+type (
+	// Phase этап жизненного цикла
+	Phase string
+
+	// Failure ошибка, произошедшая на одном из этапов
+	Failure struct {
+		Phase Phase
+		// App имя приложения или номер регистрации, пусто для ошибок всего запуска
+		App string
+		Err error
+
+		// wrapped ошибка в том виде, в котором Run возвращает ее единственной
+		wrapped error
+	}
+
+	// RunError несколько ошибок запуска в порядке их возникновения.
+	// Возвращается из Run, если ошибок больше одной.
+	RunError struct {
+		Failures []Failure
+	}
+)
+
+func (e *RunError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%d errors occurred:", len(e.Failures))
+
+	for _, f := range e.Failures {
+		b.WriteString("\n\t* ")
+		b.WriteString(string(f.Phase))
+		if f.App != "" {
+			b.WriteString(" ")
+			b.WriteString(f.App)
+		}
+		b.WriteString(": ")
+		b.WriteString(f.Err.Error())
+	}
+
+	return b.String()
+}
+
+// Unwrap возвращает ошибки в порядке их возникновения для errors.Is и errors.As.
+func (e *RunError) Unwrap() []error {
+	errs := make([]error, 0, len(e.Failures))
+	for _, f := range e.Failures {
+		errs = append(errs, f.Err)
+	}
+
+	return errs
+}
+
+// recordFailure фиксирует ошибку этапа. Для ошибок приложения i — его индекс,
+// для ошибок всего запуска — -1. wrapped — ошибка, дополненная контекстом, если
+// Run вернет ее единственной, или nil.
+func (r *Runner) recordFailure(phase Phase, i int, err, wrapped error) {
+	f := Failure{Phase: phase, Err: err, wrapped: wrapped}
+	if i >= 0 {
+		f.App = r.label(i)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.failures = append(r.failures, f)
+}
+
+// runError возвращает итоговую ошибку Run: единственную ошибку как есть, несколько —
+// как *RunError. При остановке по сигналу учитываются только превышение времени
+// остановки и ее прерывание повторным сигналом, а при WithFailOnStopError(true) —
+// и ошибки Stop и shutdown hooks.
+func (r *Runner) runError(bySignal bool) error {
+	r.mu.Lock()
+	failures := make([]Failure, 0, len(r.failures))
+	for _, f := range r.failures {
+		if bySignal && !r.failsSignalShutdown(f) {
+			continue
+		}
+		failures = append(failures, f)
+	}
+	r.mu.Unlock()
+
+	switch len(failures) {
+	case 0:
+		return nil
+	case 1:
+		if failures[0].wrapped != nil {
+			return failures[0].wrapped
+		}
+		return failures[0].Err
+	default:
+		return &RunError{Failures: failures}
+	}
+}
+
+// pendingError возвращает ошибку, с которой Run завершился бы на текущий момент
+// остановки: итоговую ошибку runError, а без ошибок при остановке по сигналу —
+// результат WithSignalMode.
+func (r *Runner) pendingError() error {
+	r.mu.Lock()
+	bySignal := r.signal != nil
+	r.mu.Unlock()
+
+	if err := r.runError(bySignal); err != nil || !bySignal {
+		return err
+	}
+
+	return r.signalResult()
+}
+
+// failsSignalShutdown сообщает, делает ли ошибка остановку по сигналу неуспешной.
+func (r *Runner) failsSignalShutdown(f Failure) bool {
+	if errors.Is(f.Err, ErrShutdownTimeout) || errors.Is(f.Err, ErrForcedShutdown) {
+		return true
+	}
+
+	return r.failOnStopError && (f.Phase == PhaseStop || f.Phase == PhaseHook)
+}
@@ -0,0 +1,63 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// codeError ошибка собственного типа для проверки errors.As
+type codeError struct {
+	code int
+}
+
+func (e *codeError) Error() string {
+	return "code error"
+}
+
+func TestAppsRunner_Run_RunError(t *testing.T) {
+	cacheApp := &MockApp{}
+	dbApp := &MockApp{}
+
+	startErr := &codeError{code: 42}
+	stopErr := errors.New("stop error")
+	hookErr := errors.New("hook error")
+
+	cacheApp.On("Start").Return(nil)
+	cacheApp.On("Stop").Return(stopErr)
+	dbApp.On("Start").After(50 * time.Millisecond).Return(startErr)
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("cache", cacheApp)
+	runner.RegisterNamedApp("db", dbApp)
+	runner.RegisterShutdownHook(func() error { return hookErr })
+
+	err := runner.Run(context.Background())
+
+	var runErr *RunError
+	require.ErrorAs(t, err, &runErr)
+	require.Len(t, runErr.Failures, 3)
+
+	assert.Equal(t, PhaseStart, runErr.Failures[0].Phase)
+	assert.Equal(t, "db", runErr.Failures[0].App)
+	assert.Equal(t, PhaseStop, runErr.Failures[1].Phase)
+	assert.Equal(t, "cache", runErr.Failures[1].App)
+	assert.Equal(t, PhaseHook, runErr.Failures[2].Phase)
+	assert.Equal(t, "#2", runErr.Failures[2].App)
+
+	assert.ErrorIs(t, err, stopErr)
+	assert.ErrorIs(t, err, hookErr)
+
+	var gotCode *codeError
+	require.ErrorAs(t, err, &gotCode)
+	assert.Equal(t, 42, gotCode.code)
+
+	assert.Equal(t, "3 errors occurred:"+
+		"\n\t* start db: code error"+
+		"\n\t* stop cache: stop error"+
+		"\n\t* hook #2: hook error", err.Error())
+}
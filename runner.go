@@ -3,9 +3,18 @@ package go_runner
 import (
 	"context"
 	"errors"
+	"fmt"
+	"io"
 	"os"
 	"os/signal"
+	"reflect"
+	"runtime"
+	"runtime/debug"
+	"slices"
+	"sync"
+	"sync/atomic"
 	"syscall"
+	"time"
 
 	"golang.org/x/sync/errgroup"
 )
@@ -13,48 +22,431 @@ import (
 type (
 	callback func() error
 
+	// contextCallback callback, получающий контекст запуска или остановки
+	contextCallback func(ctx context.Context) error
+
 	appStruct struct {
-		Name  string
-		Start callback
-		Stop  callback
+		Name        string
+		Start       contextCallback
+		Stop        contextCallback
+		Drain       contextCallback
+		Warmup      contextCallback
+		HealthCheck contextCallback
+		// PostStop hook вызывается после остальных shutdown hooks, см. RegisterPostStopHook
+		PostStop bool
+		// Build создает приложение в начале Run, см. RegisterFactory
+		Build func(ctx context.Context) (App, error)
+
+		// ContextAware приложение отслеживает отмену контекста Start
+		ContextAware bool
+
+		Priority int
+		// UnhealthyThreshold число неудачных проверок HealthCheck подряд, после
+		// которого начинается остановка, см. WithUnhealthyFatal
+		UnhealthyThreshold int
+		DependsOn          []string
+		StopOnStartError   bool
+		RestartOnReload    bool
+		StartBreaker       *CircuitBreaker
+		Domain             string
+		Fatal              bool
+		Optional           bool
+		Disabled           bool
+		StopPriority       int
+		StopOrdered        bool
+		StopFirst          bool
+		StopLast           bool
+		StopBefore         []string
+		StopAfter          []string
 	}
 
 	// app интерфейс
-	app interface {
+	app = App
+
+	// App приложение с методами запуска и остановки. Его принимает RegisterApp и
+	// возвращает фабрика RegisterFactory.
+	App interface {
 		Start() error
 		Stop() error
 	}
 
+	// ContextApp приложение, получающее контекст запуска и остановки. Контекст Start
+	// отменяется в начале остановки после паузы WithDrainDelay, контекст Stop
+	// ограничен временем остановки.
+	ContextApp interface {
+		Start(ctx context.Context) error
+		Stop(ctx context.Context) error
+	}
+
+	// Actor приложение в стиле oklog/run: Execute блокируется на все время работы,
+	// Interrupt прерывает его и получает причину остановки.
+	Actor interface {
+		Execute() error
+		Interrupt(error)
+	}
+
+	// AsyncStopper приложение с асинхронной остановкой: Stop начинает освобождение
+	// ресурсов и возвращает канал, в который будет отправлен итоговый результат.
+	AsyncStopper interface {
+		Stop() (<-chan error, error)
+	}
+
+	// AsyncApp приложение, реализующее AsyncStopper
+	AsyncApp interface {
+		Start() error
+		AsyncStopper
+	}
+
+	// Warmer приложение, которое после успешного Start выполняет прогрев, например
+	// загрузку кэша. Приложение считается запущенным после завершения Warmup.
+	Warmer interface {
+		Warmup(ctx context.Context) error
+	}
+
+	// Drainer приложение, которое перед остановкой дожидается завершения текущей
+	// работы, например обрабатываемых запросов. Drain получает контекст остановки,
+	// ограниченный временем остановки.
+	Drainer interface {
+		Drain(ctx context.Context) error
+	}
+
 	// Runner сервис для запуска приложений в режиме graceful shutdown
 	Runner struct {
 		apps   []appStruct
 		logger Logger
+
+		policy               Policy
+		signalPolicies       map[os.Signal]Policy
+		startConcurrency     int
+		sequentialStart      bool
+		shutdownOnAppExit    bool
+		tolerateLateFailures bool
+		failOnStopError      bool
+		maxStartupTime       time.Duration
+		minUptime            time.Duration
+		dryRun               bool
+		trigger              <-chan struct{}
+		withoutSignals       bool
+		signalMode           SignalMode
+		goroutineTagging     bool
+		parallelStop         bool
+		stopConcurrency      int
+		stopOrdering         StopOrdering
+		hookTimeout          time.Duration
+		progressInterval     time.Duration
+		progress             func(done, total int)
+		reExecOnReload       bool
+		verbosity            Verbosity
+		drainTimeout         time.Duration
+		readyTimeout         time.Duration
+		healthInterval       time.Duration
+		budgetFraction       float64
+		panicHandler         func(appName string, recovered any, stack []byte)
+		noRecover            bool
+		stackDump            io.Writer
+		logCancelCause       bool
+		classifier           func(err error) Severity
+		onReady              []callback
+		terminationHandler   func(err error)
+		decorators           []func(ctx context.Context) context.Context
+		signalHandlers       []signalHandler
+		messages             Messages
+
+		// notify и stopNotify подменяются в тестах для эмуляции сигналов
+		notify     func(c chan<- os.Signal, sig ...os.Signal)
+		stopNotify func(c chan<- os.Signal)
+		// exec подменяется в тестах вместо syscall.Exec
+		exec func(argv0 string, argv []string, envv []string) error
+		// exit подменяется в тестах вместо os.Exit
+		exit func(code int)
+
+		mu        sync.Mutex
+		done      chan struct{}
+		ready     chan struct{}
+		stopping  chan struct{}
+		cancel    context.CancelCauseFunc
+		appCtx    context.Context
+		lifecycle State
+		signal    os.Signal
+		states    []appState
+		startedAt time.Time
+		duration  time.Duration
+		err       error
+		cause     error
+		failures  []Failure
+
+		downDomains map[string]bool
+		// deferred функции, зарегистрированные через DeferStop
+		deferred []func() error
+		// stragglers приложения, Stop которых не завершился вовремя
+		stragglers []string
+		// readyOrder индексы приложений в порядке завершения запуска
+		readyOrder []int
+		// dynamic приложения, запущенные StartApp
+		dynamic []*dynamicApp
+		// reExecRequested SIGHUP при WithReExecOnReload запросил перезапуск процесса
+		reExecRequested bool
+
+		// signalCh и signalSet подписка текущего запуска на сигналы
+		signalCh      chan os.Signal
+		signalSet     []os.Signal
+		signalsPaused bool
+
+		// runningCount число запущенных и не остановленных приложений, читается без r.mu
+		runningCount atomic.Int64
+	}
+
+	// signalHandler обработчик сигнала, зарегистрированный RegisterSignalHandler
+	signalHandler struct {
+		signal  os.Signal
+		handler callback
+	}
+
+	// appState состояние приложения в рамках текущего запуска
+	appState struct {
+		starting      chan struct{}
+		serving       chan struct{}
+		launched      bool
+		started       bool
+		stopCalled    bool
+		drained       bool
+		stopped       bool
+		warming       bool
+		startDuration time.Duration
+		stopDuration  time.Duration
+		startErr      error
+		stopErr       error
 	}
 )
 
-// New создает новый экземпляр Runner с указанным логгером.
-func New(logger Logger) *Runner {
-	return &Runner{
-		apps:   make([]appStruct, 0),
-		logger: logger,
+// New создает новый экземпляр Runner с указанным логгером; nil заменяется на NopLogger.
+func New(logger Logger, opts ...Option) *Runner {
+	if logger == nil {
+		logger = NopLogger{}
+	}
+
+	r := &Runner{
+		apps:       make([]appStruct, 0),
+		logger:     logger,
+		messages:   defaultMessages,
+		notify:     signal.Notify,
+		stopNotify: signal.Stop,
+		exec:       syscall.Exec,
+		exit:       os.Exit,
+		done:       make(chan struct{}),
+		ready:      make(chan struct{}),
+		stopping:   make(chan struct{}),
+		lifecycle:  StateIdle,
+
+		healthInterval: defaultHealthInterval,
+	}
+
+	for _, opt := range opts {
+		opt(r)
 	}
+	r.logger = r.leveled(r.logger)
+
+	return r
+}
+
+// RunApp запускает единственное приложение и ожидает его остановки по сигналу
+// или отмене ctx — сокращение New, RegisterApp и Run для простых утилит.
+func RunApp(ctx context.Context, logger Logger, instance app, opts ...Option) error {
+	r := New(logger, opts...)
+	r.RegisterApp(instance)
+
+	return r.Run(ctx)
 }
 
 // RegisterApp регистрирует приложение, реализующее интерфейс app.
-func (r *Runner) RegisterApp(instance app) {
-	r.RegisterNamedApp("", instance)
+func (r *Runner) RegisterApp(instance app, opts ...AppOption) {
+	r.RegisterNamedApp("", instance, opts...)
 }
 
 // RegisterNamedApp регистрирует приложение с указанным именем.
-func (r *Runner) RegisterNamedApp(name string, instance app) {
-	r.apps = append(r.apps, appStruct{
+func (r *Runner) RegisterNamedApp(name string, instance app, opts ...AppOption) {
+	mustNotBeNil(name, instance)
+
+	r.register(appStruct{
+		Name:        name,
+		Start:       ignoreContext(instance.Start),
+		Stop:        ignoreContext(instance.Stop),
+		Drain:       drainer(instance),
+		Warmup:      warmer(instance),
+		HealthCheck: healthChecker(instance),
+	}, opts)
+}
+
+// RegisterApps регистрирует несколько приложений в переданном порядке.
+func (r *Runner) RegisterApps(instances ...app) {
+	for _, instance := range instances {
+		r.RegisterApp(instance)
+	}
+}
+
+// RegisterNamedApps регистрирует приложения с именами из ключей apps в порядке
+// сортировки имен.
+func (r *Runner) RegisterNamedApps(apps map[string]app) {
+	names := make([]string, 0, len(apps))
+	for name := range apps {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	for _, name := range names {
+		r.RegisterNamedApp(name, apps[name])
+	}
+}
+
+// RegisterContextApp регистрирует приложение, реализующее интерфейс ContextApp.
+// При остановке Runner дожидается возврата из Start таких приложений и вызывает
+// Stop, если Start завершился успешно.
+func (r *Runner) RegisterContextApp(name string, instance ContextApp, opts ...AppOption) {
+	mustNotBeNil(name, instance)
+
+	r.register(appStruct{
+		Name:         name,
+		Start:        instance.Start,
+		Stop:         instance.Stop,
+		Drain:        drainer(instance),
+		Warmup:       warmer(instance),
+		HealthCheck:  healthChecker(instance),
+		ContextAware: true,
+	}, opts)
+}
+
+// RegisterAppWithCleanup регистрирует приложение, Start которого возвращает
+// функцию освобождения ресурсов. При остановке вызывается эта функция вместо
+// отдельного Stop; если Start вернул ошибку, функция не вызывается.
+func (r *Runner) RegisterAppWithCleanup(name string, start func(ctx context.Context) (func() error, error), opts ...AppOption) {
+	if start == nil {
+		return
+	}
+
+	var (
+		mu      sync.Mutex
+		cleanup func() error
+	)
+
+	r.register(appStruct{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			fn, err := start(ctx)
+			if err != nil {
+				return err
+			}
+
+			mu.Lock()
+			cleanup = fn
+			mu.Unlock()
+			return nil
+		},
+		Stop: func(context.Context) error {
+			mu.Lock()
+			fn := cleanup
+			cleanup = nil
+			mu.Unlock()
+
+			if fn == nil {
+				return nil
+			}
+			return fn()
+		},
+		ContextAware: true,
+	}, opts)
+}
+
+// RegisterStartOnly регистрирует приложение, которому не требуется остановка.
+// При остановке Stop не вызывается, но в свою очередь приложение помечается
+// остановленным и перестает учитываться в RunningCount.
+func (r *Runner) RegisterStartOnly(name string, start callback, opts ...AppOption) {
+	if start == nil {
+		return
+	}
+
+	r.register(appStruct{
 		Name:  name,
-		Start: instance.Start,
-		Stop:  instance.Stop,
-	})
+		Start: ignoreContext(start),
+	}, opts)
+}
+
+// RegisterReadyApp регистрирует приложение с блокирующим start, которое само
+// сообщает о готовности: закрывает ready или отправляет в него значение, когда
+// начинает обслуживать запросы. Приложение считается запущенным по этому сигналу,
+// а не по возврату из start. Если start вернул ошибку после сигнала готовности,
+// все приложения останавливаются, как при ошибке запуска.
+func (r *Runner) RegisterReadyApp(name string, start func(ready chan<- struct{}) error, stop callback, opts ...AppOption) {
+	i := len(r.apps)
+	a := appStruct{
+		Name: name,
+		Start: func(ctx context.Context) error {
+			return r.serve(ctx, i, start)
+		},
+		ContextAware: true,
+	}
+	if stop != nil {
+		a.Stop = ignoreContext(stop)
+	}
+
+	r.register(a, opts)
+}
+
+// RegisterActor регистрирует приложение, реализующее Actor. Execute выполняется
+// как блокирующий запуск: приложение считается запущенным сразу, а ошибка Execute
+// останавливает все приложения. При остановке Interrupt получает причину отмены
+// контекста Run, при перезапуске по SIGHUP — nil.
+func (r *Runner) RegisterActor(name string, actor Actor, opts ...AppOption) {
+	mustNotBeNil(name, actor)
+
+	r.RegisterReadyApp(name, func(ready chan<- struct{}) error {
+		close(ready)
+		return actor.Execute()
+	}, func() error {
+		actor.Interrupt(r.shutdownCause())
+		return nil
+	}, opts...)
+}
+
+// RegisterAsyncApp регистрирует приложение с асинхронной остановкой. Остановка
+// приложения завершается, когда канал, возвращенный Stop, передаст результат,
+// но не позже истечения времени остановки. Ошибка, возвращенная самим Stop,
+// завершает остановку без ожидания канала.
+func (r *Runner) RegisterAsyncApp(name string, instance AsyncApp, opts ...AppOption) {
+	mustNotBeNil(name, instance)
+
+	r.register(appStruct{
+		Name:        name,
+		Start:       ignoreContext(instance.Start),
+		Stop:        awaitStop(instance),
+		Drain:       drainer(instance),
+		Warmup:      warmer(instance),
+		HealthCheck: healthChecker(instance),
+	}, opts)
+}
+
+// RegisterStartStop регистрирует приложение из отдельных функций запуска и
+// остановки, например методов объекта, не реализующего интерфейс app. Один объект
+// может быть зарегистрирован в нескольких ролях. Без start stop регистрируется
+// как shutdown hook.
+func (r *Runner) RegisterStartStop(name string, start, stop callback, opts ...AppOption) {
+	if start == nil {
+		r.RegisterShutdownHook(stop)
+		return
+	}
+
+	a := appStruct{
+		Name:  name,
+		Start: ignoreContext(start),
+	}
+	if stop != nil {
+		a.Stop = ignoreContext(stop)
+	}
+
+	r.register(a, opts)
 }
 
 // RegisterShutdownHook регистрирует функцию, которая будет вызвана при остановке приложения.
+// Shutdown hooks вызываются после остановки всех приложений в порядке, обратном регистрации.
 func (r *Runner) RegisterShutdownHook(stop callback) {
 	if stop == nil {
 		return
@@ -62,101 +454,1253 @@ func (r *Runner) RegisterShutdownHook(stop callback) {
 
 	r.apps = append(r.apps, appStruct{
 		Start: nil,
-		Stop:  stop,
+		Stop:  ignoreContext(stop),
+	})
+}
+
+// RegisterContextShutdownHook регистрирует shutdown hook, получающий контекст
+// остановки. Причину остановки возвращает ShutdownReason(ctx).
+func (r *Runner) RegisterContextShutdownHook(stop func(ctx context.Context) error) {
+	if stop == nil {
+		return
+	}
+
+	r.apps = append(r.apps, appStruct{
+		Stop: stop,
+	})
+}
+
+// RegisterShutdownHookWithError регистрирует shutdown hook, получающий контекст
+// остановки и ошибку, с которой завершился бы Run к моменту вызова hook: ошибки
+// запуска, остановки и предыдущих hooks, а при остановке по сигналу — результат
+// WithSignalMode. При штатной остановке err равна nil. Это позволяет, например,
+// сохранить снимок состояния только при аварийном завершении.
+func (r *Runner) RegisterShutdownHookWithError(stop func(ctx context.Context, err error) error) {
+	if stop == nil {
+		return
+	}
+
+	r.apps = append(r.apps, appStruct{
+		Stop: func(ctx context.Context) error {
+			return stop(ctx, r.pendingError())
+		},
 	})
 }
 
+// RegisterPostStopHook регистрирует функцию, которая вызывается последней — после
+// shutdown hooks, например для отправки накопленных метрик. Такие функции
+// вызываются в порядке регистрации.
+func (r *Runner) RegisterPostStopHook(fn callback) {
+	if fn == nil {
+		return
+	}
+
+	r.apps = append(r.apps, appStruct{
+		Stop:     ignoreContext(fn),
+		PostStop: true,
+	})
+}
+
+// RegisterCloser регистрирует shutdown hook, закрывающий c при остановке.
+// Ошибка Close дополняется именем.
+func (r *Runner) RegisterCloser(name string, c io.Closer) {
+	if c == nil {
+		return
+	}
+
+	r.apps = append(r.apps, appStruct{
+		Name: name,
+		Stop: func(context.Context) error {
+			if err := c.Close(); err != nil {
+				return fmt.Errorf("close %q: %w", name, err)
+			}
+			return nil
+		},
+	})
+}
+
+// register применяет опции и добавляет приложение, если оно не отключено
+// опцией WithEnabled(false).
+func (r *Runner) register(a appStruct, opts []AppOption) {
+	for _, opt := range opts {
+		opt(&a)
+	}
+	if a.Disabled {
+		return
+	}
+
+	r.apps = append(r.apps, a)
+}
+
+// drainer возвращает Drain приложения, если оно реализует Drainer.
+func drainer(instance any) contextCallback {
+	if d, ok := instance.(Drainer); ok {
+		return d.Drain
+	}
+
+	return nil
+}
+
+// mustNotBeNil паникует при регистрации nil приложения, в том числе nil указателя
+// в непустом интерфейсе, чтобы ошибка проявилась в месте регистрации, а не в Run.
+func mustNotBeNil(name string, instance any) {
+	if instance != nil {
+		v := reflect.ValueOf(instance)
+		switch v.Kind() {
+		case reflect.Pointer, reflect.Map, reflect.Slice, reflect.Func, reflect.Chan, reflect.Interface:
+			if !v.IsNil() {
+				return
+			}
+		default:
+			return
+		}
+	}
+
+	if name == "" {
+		panic("go_runner: nil app")
+	}
+	panic(fmt.Sprintf("go_runner: nil app %q", name))
+}
+
+// warmer возвращает Warmup приложения, если оно реализует Warmer.
+func warmer(instance any) contextCallback {
+	if w, ok := instance.(Warmer); ok {
+		return w.Warmup
+	}
+
+	return nil
+}
+
+// awaitStop адаптирует Stop приложения AsyncStopper: дожидается результата из
+// возвращенного канала или отмены контекста остановки.
+func awaitStop(s AsyncStopper) contextCallback {
+	return func(ctx context.Context) error {
+		done, err := s.Stop()
+		if err != nil || done == nil {
+			return err
+		}
+
+		select {
+		case err := <-done:
+			return err
+		case <-ctx.Done():
+			return context.Cause(ctx)
+		}
+	}
+}
+
+// ignoreContext адаптирует callback к сигнатуре с контекстом.
+func ignoreContext(cb callback) contextCallback {
+	return func(context.Context) error {
+		return cb()
+	}
+}
+
+// Run запускает зарегистрированные приложения и блокируется до их остановки.
+// Последним действием Run сбрасывает буфер логгера, если он реализует Sync или
+// Flush, и передает результат обработчику WithTerminationHandler.
 func (r *Runner) Run(ctx context.Context) error {
-	// Создаем контекст с отменой
-	ctx, cancel := context.WithCancel(ctx)
-	defer cancel()
+	r.begin()
+	err := r.run(ctx)
+	r.finish(err)
+
+	// Итоговые сообщения не должны потеряться при выходе сразу после Run
+	flushLogger(r.logger)
+
+	if r.terminationHandler != nil {
+		r.terminationHandler(err)
+	}
+
+	return err
+}
+
+// begin сбрасывает состояние предыдущего запуска.
+func (r *Runner) begin() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lifecycle = StateStarting
+	r.signal = nil
+	r.states = make([]appState, len(r.apps))
+	r.runningCount.Store(0)
+	r.startedAt = time.Now()
+	r.duration = 0
+	r.err = nil
+	r.cause = nil
+	r.failures = nil
+	r.downDomains = make(map[string]bool)
+	r.deferred = nil
+	r.stragglers = nil
+	r.reExecRequested = false
+	r.readyOrder = nil
+	r.dynamic = nil
+	r.appCtx = nil
+}
+
+// finish фиксирует результат запуска.
+func (r *Runner) finish(err error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.lifecycle = StateStopped
+	r.duration = time.Since(r.startedAt)
+	r.err = err
+
+	closeOnce(r.stopping)
+	closeOnce(r.done)
+}
+
+// closeOnce закрывает канал, если он еще не закрыт. Вызывается под r.mu.
+func closeOnce(ch chan struct{}) {
+	select {
+	case <-ch:
+	default:
+		close(ch)
+	}
+}
+
+// Done возвращает канал, который закрывается после полной остановки: когда
+// завершены Stop всех приложений и shutdown hooks. В отличие от контекста Run,
+// отменяемого в начале остановки, канал сигнализирует о ее завершении.
+func (r *Runner) Done() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.done
+}
+
+// Err возвращает ошибку, с которой завершился Run, или nil при штатной остановке.
+// До завершения Run возвращает nil.
+func (r *Runner) Err() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.lifecycle != StateStopped {
+		return nil
+	}
+
+	return r.err
+}
+
+func (r *Runner) run(ctx context.Context) error {
+	// Проверяем конфигурацию до запуска приложений
+	waves, levels, err := r.validate()
+	if err != nil {
+		r.logger.Error(r.messages.TerminatingWithError, r.messages.ErrorKey, err)
+		return err
+	}
+
+	if r.dryRun {
+		plan, _ := r.Plan()
+		r.logger.Info(r.messages.DryRun, r.messages.PlanStartKey, plan.Start, r.messages.PlanStopKey, plan.Stop)
+		return nil
+	}
+
+	// С отмененным контекстом приложения не запускаются
+	if ctx.Err() != nil {
+		err := fmt.Errorf("%w: %w", ErrContextAlreadyCancelled, context.Cause(ctx))
+		r.logger.Error(r.messages.TerminatingWithError, r.messages.ErrorKey, err)
+		return err
+	}
+
+	// Приложения RegisterFactory создаются до запуска первого приложения
+	if err := r.buildApps(ctx); err != nil {
+		r.logger.Error(r.messages.TerminatingWithError, r.messages.ErrorKey, err)
+		return err
+	}
+
+	// Резервируем часть оставшегося до дедлайна времени на остановку
+	budget := r.shutdownBudget(ctx)
+	if budget > 0 {
+		deadline, _ := ctx.Deadline()
+		var cancelBudget context.CancelFunc
+		ctx, cancelBudget = context.WithDeadline(ctx, deadline.Add(-budget))
+		defer cancelBudget()
+	}
+
+	// Создаем контекст с отменой; причина отмены доступна через context.Cause
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	// Контекст Start несет Runner для DeferStop
+	ctx = context.WithValue(ctx, runnerKey{}, r)
+	for _, decorate := range r.decorators {
+		ctx = decorate(ctx)
+	}
+
+	// Контекст, который получают приложения, отменяется не в начале остановки, а
+	// после паузы WithDrainDelay: пока закрыт только Stopping, приложения
+	// завершают текущую работу
+	appCtx, cancelApps := context.WithCancelCause(withoutCancel(ctx))
+	defer cancelApps(nil)
+
+	r.mu.Lock()
+	r.cancel = cancel
+	r.appCtx = appCtx
+	r.mu.Unlock()
 
 	// Создаем errgroup с привязкой к контексту
 	eg, ctx := errgroup.WithContext(ctx)
 
-	// Флаги для отслеживания запущенных приложений
-	started := make([]bool, len(r.apps))
+	// Запускаем все приложения. Горутины запуска не входят в errgroup, чтобы
+	// зависший Start не блокировал завершение Run после остановки.
+	startErr := make(chan error, 1)
+	startupDone := make(chan struct{})
+	go func() {
+		r.startApps(ctx, cancel, waves, startErr)
+		if ctx.Err() == nil {
+			r.transition(StateStarting, StateRunning)
+			r.runOnReady(ctx, cancel)
+			r.monitorHealth(ctx, cancel)
+		}
+		close(startupDone)
+	}()
 
-	// Запускаем все приложения
-	for i, a := range r.apps {
-		if a.Start == nil {
-			continue
+	// Ожидание запуска
+	eg.Go(func() error {
+		return r.awaitStartup(ctx, cancel, startErr, startupDone)
+	})
+
+	// Graceful shutdown. Повторный сигнал закрывает force и прерывает остановку
+	// приложений, stopped закрывается по завершении остановки.
+	force := make(chan struct{})
+	stopped := make(chan struct{})
+	eg.Go(func() error {
+		defer close(stopped)
+		<-ctx.Done()
+
+		// Причина остановки добавляется в то же сообщение, чтобы ее было видно сразу
+		initiatedAt := time.Now()
+		cause := context.Cause(ctx)
+		fields := []any{r.messages.AtKey, initiatedAt}
+		if r.logCancelCause {
+			fields = append(fields, r.messages.CauseKey, cause.Error())
 		}
+		r.logger.Info(r.messages.ShutdownInitiated, fields...)
 
-		r.logger.Debug("start application", "app", a.Name)
+		r.mu.Lock()
+		r.lifecycle = StateStopping
+		r.cause = cause
+		closeOnce(r.stopping)
+		r.mu.Unlock()
+		r.warnNotStarted()
 
-		// Запускаем приложение в отдельной горутине
-		eg.Go(func() error {
-			err := a.Start()
-			if err != nil {
-				r.logger.Debug("application finished", "app", a.Name, "error", err)
-				cancel() // Отменяем контекст при ошибке
-				return err
+		policy := r.shutdownPolicy()
+		if budget > 0 {
+			policy = policy.withBudget(budget)
+		}
+		if policy.DrainDelay > 0 {
+			r.logger.Debug(r.messages.DrainingBeforeStop, r.messages.DelayKey, policy.DrainDelay)
+			time.Sleep(policy.DrainDelay)
+		}
+		cancelApps(cause)
+
+		// Порядок завершения запуска известен только к началу остановки
+		stopLevels := levels
+		if r.stopOrdering == StopReverseReady {
+			stopLevels = r.pinLevels(r.reverseReadyLevels(levels))
+		}
+
+		// Контекст остановки сохраняет значения контекста Run, но не его отмену
+		stopCtx := context.WithoutCancel(ctx)
+		r.drainApps(r.withReason(stopCtx), stopLevels, force)
+		shutdownErr := r.stopApps(r.withReason(stopCtx), stopLevels, policy.ShutdownTimeout, force)
+
+		hookTimeout := r.hookTimeout
+		if hookTimeout == 0 {
+			hookTimeout = policy.ShutdownTimeout
+		}
+		if hookErr := r.runHooks(r.withReason(stopCtx), hookTimeout); hookErr != nil {
+			shutdownErr = hookErr
+		}
+
+		completedAt := time.Now()
+		r.logger.Info(r.messages.ShutdownCompleted, r.messages.AtKey, completedAt, r.messages.DurationKey, completedAt.Sub(initiatedAt))
+
+		return shutdownErr
+	})
+
+	// Обработка сигналов
+	eg.Go(func() error {
+		return r.handleSignals(ctx, cancel, waves, startupDone, force, stopped)
+	})
+
+	_ = eg.Wait()
+
+	r.mu.Lock()
+	bySignal := r.signal != nil
+	r.mu.Unlock()
+	if bySignal {
+		r.logger.Debug(r.messages.ShuttingDownBySignal)
+	}
+
+	err = r.runError(bySignal)
+	if err != nil {
+		r.logger.Error(r.messages.TerminatingWithError, r.messages.ErrorKey, err)
+	}
+
+	r.logger.Info(r.messages.ApplicationStopped, r.summaryFields()...)
+
+	if err == nil && r.reExecPending() {
+		if err := r.reExec(); err != nil {
+			r.logger.Error(r.messages.TerminatingWithError, r.messages.ErrorKey, err)
+			return err
+		}
+		return nil
+	}
+
+	if err == nil && bySignal {
+		return r.signalResult()
+	}
+	return err
+}
+
+// startApps запускает приложения волнами, вычисленными resolve: следующая волна
+// запускается после того, как запущены все приложения предыдущей. Внутри волны
+// выполняется не более startConcurrency Start одновременно, при WithSequentialStart —
+// по одному. Первая ошибка запуска отправляется в startErr, ошибка в домене отказа
+// останавливает только этот домен. После начала остановки новые приложения не
+// запускаются.
+func (r *Runner) startApps(ctx context.Context, cancel context.CancelCauseFunc, waves [][]int, startErr chan<- error) {
+	for _, wave := range waves {
+		var eg errgroup.Group
+		switch {
+		case r.sequentialStart:
+			eg.SetLimit(1)
+		case r.startConcurrency > 0:
+			eg.SetLimit(r.startConcurrency)
+		}
+
+		for _, i := range wave {
+			if ctx.Err() != nil {
+				break
 			}
 
-			// Помечаем приложение как запущенное только в случае успеха
-			started[i] = true
-			r.logger.Debug("application started", "app", a.Name)
-			return nil
+			// Запускаем приложение в отдельной горутине
+			eg.Go(func() error {
+				// Слот мог освободиться уже после начала остановки или остановки домена
+				domain := r.apps[i].Domain
+				if ctx.Err() != nil || r.domainDown(domain) {
+					return nil
+				}
+
+				err := r.startApp(ctx, i)
+				if err == nil {
+					// Домен мог быть остановлен, пока приложение запускалось
+					if r.domainDown(domain) {
+						_ = r.stopApp(context.WithoutCancel(ctx), i)
+					}
+					return nil
+				}
+
+				// Ошибка в домене отказа останавливает только этот домен
+				if r.isolate(ctx, i, waves, err) {
+					return nil
+				}
+
+				select {
+				case startErr <- err:
+				default:
+				}
+				cancel(startFailed(err)) // Отменяем контекст при ошибке
+				return err
+			})
+		}
+
+		_ = eg.Wait()
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+// startApp запускает приложение с индексом i и фиксирует результат в его состоянии.
+// Приложение, реализующее Warmer, помечается запущенным после Warmup. Если Start
+// или Warmup прерваны отменой контекста во время остановки, возвращает nil, не
+// помечая приложение запущенным.
+func (r *Runner) startApp(ctx context.Context, i int) error {
+	a := r.apps[i]
+	runCtx := ctx
+	ctx = r.withApp(r.appContext(ctx), i, PhaseStart)
+	r.logger.Debug(r.messages.StartApplication, r.appFields(i)...)
+	r.updateState(i, func(st *appState) { st.launched = true })
+
+	// Остановка дожидается возврата из Start приложений, отслеживающих контекст
+	if a.ContextAware {
+		starting := make(chan struct{})
+		r.updateState(i, func(st *appState) { st.starting = starting })
+		defer close(starting)
+	}
+
+	startedAt := time.Now()
+	err := r.classify(i, r.callStart(ctx, i))
+
+	// Не дождавшееся готовности приложение продолжает работу, поэтому Stop
+	// вызывается, чтобы его завершить
+	warming := errors.Is(err, ErrReadyTimeout)
+
+	// После успешного Start приложение прогревается. Если прогрев не завершен,
+	// приложение не считается запущенным, но Stop освобождает занятые им ресурсы.
+	if err == nil && a.Warmup != nil {
+		r.logger.Debug(r.messages.WarmupApplication, r.appFields(i)...)
+		if err = r.warmupApp(ctx, i); err != nil {
+			err = fmt.Errorf("warmup: %w", err)
+			warming = true
+		}
+	}
+
+	// Прерванный остановкой запуск не является ошибкой. Отмена без начала
+	// остановки по-прежнему считается ошибкой приложения.
+	if err != nil && runCtx.Err() != nil && isCancellation(err) {
+		r.updateState(i, func(st *appState) {
+			st.launched = false
+			st.startDuration = time.Since(startedAt)
+			st.warming = warming
+			st.stopCalled = false
+			st.drained = false
+			st.stopped = false
 		})
+		r.logger.Debug(r.messages.StartCancelled, r.appFields(i)...)
+		return nil
 	}
 
-	// Graceful shutdown
-	eg.Go(func() error {
-		<-ctx.Done()
+	// Помечаем приложение как запущенное только в случае успеха
+	r.updateState(i, func(st *appState) {
+		st.launched = false
+		st.startDuration = time.Since(startedAt)
+		st.startErr = err
+		st.started = err == nil
+		st.warming = warming
+		st.stopCalled = false
+		st.drained = false
+		st.stopped = false
+	})
 
-		var err error
-		// Останавливаем только запущенные приложения
-		for i, a := range r.apps {
-			if a.Stop == nil || !started[i] {
-				continue
-			}
+	// Ошибка запуска необязательного приложения не прерывает Run
+	if err != nil && a.Optional {
+		r.logger.Warn(r.messages.OptionalStartFailed, r.appFields(i, r.messages.ErrorKey, err)...)
+		return nil
+	}
+
+	if err != nil {
+		r.logger.Debug(r.messages.ApplicationFinished, r.appFields(i, r.messages.ErrorKey, err)...)
+		wrapped := r.appError(i, "start", err)
+		r.recordFailure(PhaseStart, i, err, wrapped)
+		return wrapped
+	}
+
+	r.logger.Debug(r.messages.ApplicationStarted, r.appFields(i)...)
+	return nil
+}
+
+// appContext возвращает контекст приложений текущего запуска, который
+// отменяется после паузы перед остановкой, или ctx вне Run.
+func (r *Runner) appContext(ctx context.Context) context.Context {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.appCtx == nil {
+		return ctx
+	}
+
+	return r.appCtx
+}
+
+// callStart вызывает Start приложения с индексом i, при WithStartCircuitBreaker —
+// с повторными попытками. Пока выключатель разомкнут, попытки не выполняются;
+// по истечении Cooldown выполняется одна пробная попытка, неудача которой снова
+// размыкает выключатель. Попытки прекращаются при отмене контекста запуска.
+func (r *Runner) callStart(ctx context.Context, i int) error {
+	cb := r.apps[i].StartBreaker
+	if cb == nil {
+		return r.safeCall(ctx, i, r.apps[i].Start)
+	}
 
-			r.logger.Debug("stop application", "app", a.Name)
-			if stopErr := a.Stop(); stopErr != nil {
-				r.logger.Error("application stop error", "app", a.Name, "error", stopErr)
-				err = stopErr
+	failures := 0
+	for attempt := 1; ; attempt++ {
+		err := r.safeCall(ctx, i, r.apps[i].Start)
+		if err == nil || ctx.Err() != nil || (cb.MaxAttempts > 0 && attempt >= cb.MaxAttempts) {
+			return err
+		}
+
+		failures++
+		delay, open := cb.RetryDelay, cb.Threshold > 0 && failures >= cb.Threshold
+		if open {
+			r.logger.Warn(r.messages.BreakerOpen, r.appFields(i, r.messages.FailuresKey, failures, r.messages.CooldownKey, cb.Cooldown, r.messages.ErrorKey, err)...)
+			delay = cb.Cooldown
+			failures = cb.Threshold - 1
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		}
+
+		if open {
+			r.logger.Debug(r.messages.BreakerHalfOpen, r.appFields(i)...)
+		}
+	}
+}
+
+// serve вызывает start приложения с индексом i в отдельной горутине и возвращает
+// управление по сигналу готовности, при возврате из start или в начале остановки.
+// Ошибка start после сигнала готовности отменяет контекст Run, если остановка еще
+// не началась, а при WithShutdownOnAppExit — и возврат без ошибки.
+func (r *Runner) serve(ctx context.Context, i int, start func(ready chan<- struct{}) error) error {
+	ready := make(chan struct{}, 1)
+	exit := make(chan error, 1)
+
+	// Остановка дожидается возврата из start после Stop
+	serving := make(chan struct{})
+	r.updateState(i, func(st *appState) { st.serving = serving })
+	go func() {
+		defer close(serving)
+		exit <- r.safeCall(ctx, i, func(context.Context) error {
+			return start(ready)
+		})
+	}()
+
+	// При WithReadyTimeout ожидание сигнала готовности ограничено
+	var timeout <-chan time.Time
+	if r.readyTimeout > 0 {
+		timer := time.NewTimer(r.readyTimeout)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	// Остановка до сигнала готовности не прерывает start, поэтому приложение
+	// считается запущенным и Stop вызывается, чтобы его завершить
+	select {
+	case <-ready:
+	case err := <-exit:
+		return err
+	case <-timeout:
+		return ErrReadyTimeout
+	case <-ctx.Done():
+	}
+
+	go func() {
+		err := r.classify(i, <-exit)
+		if ctx.Err() != nil || r.shutdownCause() != nil {
+			return
+		}
+
+		if err == nil {
+			if r.shutdownOnAppExit {
+				r.logger.Warn(r.messages.ApplicationExited, r.appFields(i)...)
+				r.cancelRun(ErrAppExited)
 			}
+			return
 		}
 
-		// Вызываем shutdown hook
-		for _, a := range r.apps {
-			if a.Start == nil && a.Stop != nil { // Это shutdown hook
-				r.logger.Debug("calling shutdown hook", "app", a.Name)
-				if hookErr := a.Stop(); hookErr != nil {
-					r.logger.Error("shutdown hook error", "app", a.Name, "error", hookErr)
-					err = hookErr
-				}
+		// После завершения запуска ошибка при WithTolerateLateFailures останавливает
+		// только это приложение
+		if r.tolerateLateFailures && r.State() == StateRunning {
+			r.logger.Error(r.messages.LateFailureTolerated, r.appFields(i, r.messages.ErrorKey, err)...)
+			r.updateState(i, func(st *appState) { st.startErr = err })
+			_ = r.stopApp(context.WithoutCancel(ctx), i)
+			return
+		}
+
+		r.logger.Debug(r.messages.ApplicationFinished, r.appFields(i, r.messages.ErrorKey, err)...)
+		wrapped := r.appError(i, "start", err)
+		r.recordFailure(PhaseStart, i, err, wrapped)
+
+		r.updateState(i, func(st *appState) { st.startErr = err })
+		r.cancelRun(startFailed(wrapped))
+	}()
+
+	return nil
+}
+
+// cancelRun отменяет контекст текущего запуска с указанной причиной.
+func (r *Runner) cancelRun(cause error) {
+	r.mu.Lock()
+	cancel := r.cancel
+	r.mu.Unlock()
+
+	cancel(cause)
+}
+
+// safeCall вызывает fn приложения с индексом i, преобразуя панику в ошибку ErrPanic.
+func (r *Runner) safeCall(ctx context.Context, i int, fn contextCallback) error {
+	return r.recoverCall(r.apps[i].Name, r.appFields(i), func() error { return fn(ctx) })
+}
+
+// recoverCall вызывает fn приложения name, преобразуя панику в ошибку ErrPanic.
+// Перед преобразованием восстановленная паника передается обработчику
+// WithPanicHandler, а без него записывается в лог с полями fields. При
+// WithRecover(false) паника не восстанавливается.
+func (r *Runner) recoverCall(name string, fields []any, fn func() error) (err error) {
+	// Без восстановления паника завершает процесс с исходным стеком
+	if r.noRecover {
+		return fn()
+	}
+
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			stack := debug.Stack()
+			if r.panicHandler != nil {
+				r.panicHandler(name, recovered, stack)
+			} else {
+				r.logger.Error(r.messages.ApplicationPanic, append(fields, r.messages.PanicKey, recovered, r.messages.StackKey, string(stack))...)
 			}
+
+			err = fmt.Errorf("%w: %v", ErrPanic, recovered)
 		}
+	}()
+
+	return fn()
+}
+
+// dumpStacks записывает стеки всех горутин в writer WithStackDumpOnTimeout.
+func (r *Runner) dumpStacks() {
+	if r.stackDump == nil {
+		return
+	}
+
+	buf := make([]byte, 64<<10)
+	for {
+		n := runtime.Stack(buf, true)
+		if n < len(buf) {
+			buf = buf[:n]
+			break
+		}
+		buf = make([]byte, 2*len(buf))
+	}
+
+	_, _ = r.stackDump.Write(buf)
+}
+
+// appFields возвращает поля лога для приложения с индексом i, дополненные args.
+// При WithGoroutineTagging добавляется стабильный номер приложения.
+func (r *Runner) appFields(i int, args ...any) []any {
+	fields := []any{r.messages.AppKey, r.apps[i].Name}
+	if r.goroutineTagging {
+		fields = append(fields, r.messages.SeqKey, i)
+	}
+
+	return append(fields, args...)
+}
+
+// startFailed возвращает причину отмены контекста для ошибки запуска приложения.
+func startFailed(err error) error {
+	return fmt.Errorf("%w: %w", ErrStartFailed, err)
+}
+
+// isCancellation сообщает, что ошибка вызвана отменой контекста.
+func isCancellation(err error) bool {
+	return errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded)
+}
+
+// appError дополняет ошибку этапа verb именем приложения, а для безымянного —
+// номером регистрации.
+func (r *Runner) appError(i int, verb string, err error) error {
+	if r.apps[i].Name == "" {
+		return fmt.Errorf("app #%d %s: %w", i, verb, err)
+	}
+
+	return fmt.Errorf("app %q %s: %w", r.apps[i].Name, verb, err)
+}
+
+// warmupApp вызывает Warmup приложения i. При WithReadyTimeout ожидание Warmup
+// ограничено этим временем: по его истечении контекст Warmup отменяется, а
+// warmupApp возвращает ErrReadyTimeout, не дожидаясь возврата из Warmup.
+func (r *Runner) warmupApp(ctx context.Context, i int) error {
+	if r.readyTimeout <= 0 {
+		return r.safeCall(ctx, i, r.apps[i].Warmup)
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, r.readyTimeout, ErrReadyTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.safeCall(ctx, i, r.apps[i].Warmup)
+	}()
 
+	select {
+	case err := <-done:
+		if err != nil && errors.Is(context.Cause(ctx), ErrReadyTimeout) {
+			return ErrReadyTimeout
+		}
 		return err
-	})
+	case <-ctx.Done():
+		if errors.Is(context.Cause(ctx), ErrReadyTimeout) {
+			return ErrReadyTimeout
+		}
+		// Прерванный остановкой прогрев завершается как без ограничения
+		return <-done
+	}
+}
 
-	// Обработка сигнала завершения
-	eg.Go(func() error {
-		sig := []os.Signal{syscall.SIGTERM, syscall.SIGINT}
-		ch := make(chan os.Signal, len(sig))
-		signal.Notify(ch, sig...)
+// drainApps при WithDrainTimeout выполняет Drain как отдельный этап остановки:
+// вызывает Drain всех приложений, которые будут остановлены, одновременно и
+// дожидается их не дольше drainTimeout, после чего начинается остановка. Время
+// этапа не входит в WithShutdownTimeout. Закрытие force прерывает ожидание.
+// Ожидание возврата из Start приложений с контекстом входит во время этапа;
+// если Start не вернулся до его истечения, Drain вызывается при остановке.
+func (r *Runner) drainApps(ctx context.Context, levels [][]int, force <-chan struct{}) {
+	if r.drainTimeout <= 0 {
+		return
+	}
 
+	ctx, cancel := context.WithTimeoutCause(ctx, r.drainTimeout, ErrDrainTimeout)
+	defer cancel()
+
+	started := make(chan struct{})
+	go func() {
+		r.awaitContextStarts()
+		close(started)
+	}()
+
+	select {
+	case <-started:
+	case <-ctx.Done():
+		return
+	case <-force:
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, i := range slices.Concat(levels...) {
+		if r.apps[i].Drain == nil || r.apps[i].Stop == nil || !r.needsStop(i) {
+			continue
+		}
+
+		// Stop этого приложения не вызывает Drain повторно
+		r.updateState(i, func(st *appState) { st.drained = true })
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			r.drainApp(r.withApp(ctx, i, PhaseStop), i)
+		}()
+	}
+
+	done := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-force:
+	}
+}
+
+// drainApp вызывает Drain приложения i. При WithDrainTimeout ожидание Drain
+// ограничено этим временем: по его истечении остановка переходит к Stop, не
+// дожидаясь возврата из Drain.
+func (r *Runner) drainApp(ctx context.Context, i int) {
+	r.logger.Debug(r.messages.DrainApplication, r.appFields(i)...)
+
+	if r.drainTimeout <= 0 {
+		if err := r.safeCall(ctx, i, r.apps[i].Drain); err != nil {
+			r.logger.Warn(r.messages.DrainError, r.appFields(i, r.messages.ErrorKey, err)...)
+		}
+		return
+	}
+
+	ctx, cancel := context.WithTimeoutCause(ctx, r.drainTimeout, ErrDrainTimeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- r.safeCall(ctx, i, r.apps[i].Drain)
+	}()
+
+	select {
+	case err := <-done:
+		if err != nil {
+			r.logger.Warn(r.messages.DrainError, r.appFields(i, r.messages.ErrorKey, err)...)
+		}
+	case <-ctx.Done():
+		if errors.Is(context.Cause(ctx), ErrDrainTimeout) {
+			r.logger.Warn(r.messages.DrainTimeout, r.appFields(i, r.messages.TimeoutKey, r.drainTimeout)...)
+		}
+	}
+}
+
+// stopApp останавливает приложение с индексом i и фиксирует ошибку остановки.
+// Приложение, реализующее Drainer, перед Stop дожидается завершения Drain, если
+// он не был выполнен этапом drainApps; ошибка Drain логируется и не прерывает
+// остановку.
+// Stop вызывается не более одного раза после каждого запуска приложения,
+// независимо от того, каким путем была начата остановка. Для приложений
+// RegisterReadyApp и RegisterActor остановка завершается после возврата из
+// start или отмены ctx.
+func (r *Runner) stopApp(ctx context.Context, i int) error {
+	if !r.claimStop(i) {
+		return nil
+	}
+
+	a := r.apps[i]
+	ctx = r.withApp(ctx, i, PhaseStop)
+
+	// Приложение дожидается завершения текущей работы до вызова Stop
+	if a.Drain != nil && !r.state(i).drained {
+		r.drainApp(ctx, i)
+	}
+
+	r.logger.Debug(r.messages.StopApplication, r.appFields(i)...)
+
+	stoppedAt := time.Now()
+	err := r.classify(i, r.safeCall(ctx, i, a.Stop))
+
+	// Приложение с блокирующим start остановлено, когда start вернул управление
+	if serving := r.state(i).serving; serving != nil {
 		select {
-		case <-ch:
-			cancel()
-			return ErrInterruptedBySignal
+		case <-serving:
 		case <-ctx.Done():
-			return nil
 		}
+	}
+
+	r.updateState(i, func(st *appState) {
+		st.stopped = true
+		st.stopDuration = time.Since(stoppedAt)
+		st.stopErr = err
 	})
 
-	if err := eg.Wait(); err != nil {
-		if errors.Is(err, ErrInterruptedBySignal) {
-			r.logger.Debug("shutting down by signal")
+	if err != nil {
+		r.logger.Error(r.messages.StopError, r.appFields(i, r.messages.ErrorKey, err)...)
+		r.recordFailure(PhaseStop, i, err, nil)
+	}
+
+	return err
+}
+
+// claimStop отмечает, что Stop приложения с индексом i вызван. Возвращает false,
+// если Stop уже вызывался после последнего запуска.
+func (r *Runner) claimStop(i int) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.states[i].stopCalled {
+		return false
+	}
+
+	r.states[i].stopCalled = true
+	return true
+}
+
+// awaitStartup ожидает завершения запуска и возвращает первую ошибку запуска.
+// При заданном maxStartupTime не успевший запуститься набор приложений
+// останавливается с ошибкой ErrStartupTimeout.
+func (r *Runner) awaitStartup(ctx context.Context, cancel context.CancelCauseFunc, startErr <-chan error, startupDone <-chan struct{}) error {
+	var timeout <-chan time.Time
+	if r.maxStartupTime > 0 {
+		timer := time.NewTimer(r.maxStartupTime)
+		defer timer.Stop()
+		timeout = timer.C
+	}
+
+	select {
+	case err := <-startErr:
+		return err
+	case <-timeout:
+		r.logger.Error(r.messages.StartupTimeout, r.messages.TimeoutKey, r.maxStartupTime)
+		r.recordFailure(PhaseStart, -1, ErrStartupTimeout, nil)
+		cancel(ErrStartupTimeout)
+		return ErrStartupTimeout
+	case <-startupDone:
+	case <-ctx.Done():
+	}
+
+	// Ошибка запуска могла быть отправлена одновременно с завершением запуска или отменой
+	select {
+	case err := <-startErr:
+		return err
+	default:
+		return nil
+	}
+}
+
+// state возвращает копию состояния приложения с индексом i.
+func (r *Runner) state(i int) appState {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.states[i]
+}
+
+// updateState изменяет состояние приложения с индексом i под блокировкой.
+func (r *Runner) updateState(i int, fn func(st *appState)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	wasRunning := r.states[i].running()
+	fn(&r.states[i])
+
+	switch running := r.states[i].running(); {
+	case running && !wasRunning:
+		r.runningCount.Add(1)
+		r.readyOrder = append(r.readyOrder, i)
+	case !running && wasRunning:
+		r.runningCount.Add(-1)
+	}
+}
+
+// awaitContextStarts дожидается возврата из Start приложений, отслеживающих
+// контекст, которые выполнялись к началу остановки.
+func (r *Runner) awaitContextStarts() {
+	r.mu.Lock()
+	var pending []chan struct{}
+	for _, st := range r.states {
+		if st.starting != nil {
+			pending = append(pending, st.starting)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, starting := range pending {
+		<-starting
+	}
+}
+
+// warnNotStarted логирует приложения без контекста, Start которых вызван, но к
+// началу остановки так и не завершился. Такие приложения не останавливаются,
+// поэтому их Start, скорее всего, блокируется без RegisterReadyApp. Start
+// приложений с контекстом прерывается отменой и дожидается остановкой.
+func (r *Runner) warnNotStarted() {
+	r.mu.Lock()
+	var pending []int
+	for i, st := range r.states {
+		if st.launched && !r.apps[i].ContextAware {
+			pending = append(pending, i)
+		}
+	}
+	r.mu.Unlock()
+
+	for _, i := range pending {
+		r.logger.Warn(r.messages.NeverStarted, r.appFields(i)...)
+	}
+}
+
+// needsStop сообщает, нужно ли вызывать Stop приложения с индексом i.
+func (r *Runner) needsStop(i int) bool {
+	st := r.state(i)
+	if st.started || st.warming {
+		return true
+	}
+
+	// Частично инициализированное приложение освобождает ресурсы в Stop
+	return r.apps[i].StopOnStartError && st.startErr != nil
+}
+
+// shutdownCause возвращает причину отмены контекста Run или nil до начала остановки.
+func (r *Runner) shutdownCause() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.cause
+}
+
+// shutdownBudget возвращает время, резервируемое на остановку при
+// WithAutoShutdownBudget, или ноль, если у контекста Run нет дедлайна.
+func (r *Runner) shutdownBudget(ctx context.Context) time.Duration {
+	if r.budgetFraction <= 0 {
+		return 0
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return 0
+	}
+
+	return time.Duration(float64(time.Until(deadline)) * r.budgetFraction)
+}
+
+// shutdownPolicy возвращает политику остановки с учетом полученного сигнала.
+func (r *Runner) shutdownPolicy() Policy {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if p, ok := r.signalPolicies[r.signal]; ok && r.signal != nil {
+		return p
+	}
+
+	return r.policy
+}
+
+// runHooks вызывает функции DeferStop, затем shutdown hooks в порядке hookOrder.
+// При ненулевом timeout ожидание прерывается с ошибкой ErrShutdownTimeout, а
+// зависший hook логируется.
+func (r *Runner) runHooks(ctx context.Context, timeout time.Duration) error {
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
+	var (
+		mu      sync.Mutex
+		pending int
+	)
+	done := make(chan error, 1)
+	go func() {
+		mu.Lock()
+		pending = -1
+		mu.Unlock()
+
+		err := r.runDeferred()
+		for _, i := range r.hookOrder() {
+			a := r.apps[i]
+
+			mu.Lock()
+			pending = i
+			mu.Unlock()
+
+			r.logger.Debug(r.messages.CallingShutdownHook, r.appFields(i)...)
+			if hookErr := r.safeCall(r.withApp(ctx, i, PhaseHook), i, a.Stop); hookErr != nil {
+				r.logger.Error(r.messages.ShutdownHookError, r.appFields(i, r.messages.ErrorKey, hookErr)...)
+				r.recordFailure(PhaseHook, i, hookErr, nil)
+				err = hookErr
+			}
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		mu.Lock()
+		i := pending
+		mu.Unlock()
+
+		if i < 0 {
+			r.logger.Error(r.messages.HookTimeout, r.messages.TimeoutKey, timeout)
 		} else {
-			r.logger.Error("terminating with error", "error", err)
-			return err
+			r.logger.Error(r.messages.HookTimeout, r.appFields(i, r.messages.TimeoutKey, timeout)...)
 		}
+		r.recordFailure(PhaseHook, i, ErrShutdownTimeout, nil)
+		r.dumpStacks()
+		return ErrShutdownTimeout
 	}
+}
 
-	r.logger.Info("application was stopped")
-	return nil
+// hookOrder возвращает индексы shutdown hooks в порядке вызова: сначала hooks в
+// порядке, обратном регистрации, затем RegisterPostStopHook в порядке регистрации.
+func (r *Runner) hookOrder() []int {
+	var hooks, postStop []int
+	for i, a := range r.apps {
+		switch {
+		case a.Start != nil || a.Stop == nil: // Не shutdown hook
+		case a.PostStop:
+			postStop = append(postStop, i)
+		default:
+			hooks = append(hooks, i)
+		}
+	}
+	slices.Reverse(hooks)
+
+	return append(hooks, postStop...)
+}
+
+// stopApps дожидается возврата из Start приложений, отслеживающих контекст, и
+// останавливает запущенные приложения по уровням: следующий уровень — после
+// остановки предыдущего. При WithParallelStop приложения одного уровня
+// останавливаются параллельно, не более stopConcurrency одновременно. Контекст
+// Stop отменяется по истечении ненулевого timeout или при закрытии force — что
+// произойдет раньше; ожидание остановки при этом прерывается с ошибкой
+// ErrShutdownTimeout или ErrForcedShutdown, а приложения с незавершенным Stop
+// фиксируются как stragglers. Ход остановки передается обработчику
+// WithShutdownProgress до возврата.
+func (r *Runner) stopApps(ctx context.Context, levels [][]int, timeout time.Duration, force <-chan struct{}) error {
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	if timeout > 0 {
+		var cancelTimeout context.CancelFunc
+		ctx, cancelTimeout = context.WithTimeoutCause(ctx, timeout, ErrShutdownTimeout)
+		defer cancelTimeout()
+	}
+
+	go func() {
+		select {
+		case <-force:
+			cancel(ErrForcedShutdown)
+		case <-ctx.Done():
+		}
+	}()
+
+	var progress stopProgress
+	stopTracking := r.trackProgress(&progress)
+	defer stopTracking()
+
+	done := make(chan error, 1)
+	go func() {
+		r.awaitContextStarts()
+
+		// Приложения StartApp запущены последними и останавливаются первыми
+		err := r.stopDynamics()
+
+		var total int64
+		for _, i := range slices.Concat(levels...) {
+			if r.apps[i].Stop != nil && r.needsStop(i) {
+				total++
+			}
+		}
+		progress.total.Store(total)
+
+		var mu sync.Mutex
+		for _, level := range levels {
+			var eg errgroup.Group
+			switch {
+			case !r.parallelStop:
+				eg.SetLimit(1)
+			case r.stopConcurrency > 0:
+				eg.SetLimit(r.stopConcurrency)
+			}
+
+			// Останавливаем только запущенные приложения
+			for _, i := range level {
+				// Приложение без Stop считается остановленным в свою очередь
+				if r.apps[i].Stop == nil {
+					eg.Go(func() error {
+						r.updateState(i, func(st *appState) { st.stopped = st.stopped || st.started })
+						return nil
+					})
+					continue
+				}
+				if !r.needsStop(i) {
+					continue
+				}
+
+				eg.Go(func() error {
+					if stopErr := r.stopApp(ctx, i); stopErr != nil {
+						mu.Lock()
+						err = stopErr
+						mu.Unlock()
+					}
+					progress.done.Add(1)
+					return nil
+				})
+			}
+
+			_ = eg.Wait()
+		}
+		done <- err
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		err := context.Cause(ctx)
+		if errors.Is(err, ErrForcedShutdown) {
+			r.logger.Error(r.messages.ShutdownForced)
+		} else {
+			r.logger.Error(r.messages.ShutdownTimeout, r.messages.TimeoutKey, timeout)
+			r.dumpStacks()
+		}
+		r.reportStragglers()
+		r.recordFailure(PhaseStop, -1, err, nil)
+		return err
+	}
 }
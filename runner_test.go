@@ -1,9 +1,16 @@
 package go_runner
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"os/exec"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
@@ -61,7 +68,7 @@ func TestAppsRunner_Run_Success(t *testing.T) {
 	loggerMock.On("Debug", "start application", "app", "").Once()
 	loggerMock.On("Debug", "application started", "app", "").Once()
 	loggerMock.On("Debug", "stop application", "app", "").Once()
-	loggerMock.On("Info", "application was stopped").Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
 
 	runner := New(loggerMock)
 	runner.RegisterApp(appMock)
@@ -87,7 +94,8 @@ func TestAppsRunner_Run_StartError(t *testing.T) {
 	// Ожидаем вызов Debug для запуска и завершения приложения
 	loggerMock.On("Debug", "start application", "app", "").Once()
 	loggerMock.On("Debug", "application finished", "app", "", "error", expectedErr).Once()
-	loggerMock.On("Error", "terminating with error", "error", expectedErr).Once()
+	loggerMock.On("Error", "terminating with error", "error", fmt.Errorf("app #0 start: %w", expectedErr)).Once()
+	onStopped(loggerMock, 0, 1, 0).Once()
 
 	runner := New(loggerMock)
 	runner.RegisterApp(appMock)
@@ -96,8 +104,8 @@ func TestAppsRunner_Run_StartError(t *testing.T) {
 	defer cancel()
 
 	err := runner.Run(ctx)
-	require.Error(t, err)
-	assert.Equal(t, expectedErr, err)
+	require.ErrorIs(t, err, expectedErr)
+	assert.EqualError(t, err, "app #0 start: start error")
 
 	appMock.AssertExpectations(t)
 	loggerMock.AssertExpectations(t)
@@ -119,6 +127,8 @@ func TestAppsRunner_Run_StopError(t *testing.T) {
 	loggerMock.On("Debug", "stop application", "app", "").Once()
 	loggerMock.On("Error", "application stop error", "app", "", "error", expectedErr).Once()
 	loggerMock.On("Error", "terminating with error", "error", expectedErr).Once()
+	// Итоговая строка учитывает ошибку остановки
+	onStopped(loggerMock, 1, 0, 1).Once()
 
 	runner := New(loggerMock)
 	runner.RegisterApp(appMock)
@@ -146,7 +156,7 @@ func TestAppsRunner_Run_ShutdownBySignal(t *testing.T) {
 	loggerMock.On("Debug", "application started", "app", "").Once()
 	loggerMock.On("Debug", "stop application", "app", "").Once()
 	loggerMock.On("Debug", "shutting down by signal").Once()
-	loggerMock.On("Info", "application was stopped").Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
 
 	runner := New(loggerMock)
 	runner.RegisterApp(appMock)
@@ -186,7 +196,7 @@ func TestAppsRunner_RegisterShutdownHook(t *testing.T) {
 	loggerMock.On("Debug", "application started", "app", "").Once()
 	loggerMock.On("Debug", "stop application", "app", "").Once()
 	loggerMock.On("Debug", "calling shutdown hook", "app", "").Once()
-	loggerMock.On("Info", "application was stopped").Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
 
 	runner := New(loggerMock)
 	runner.RegisterApp(appMock)
@@ -202,3 +212,2157 @@ func TestAppsRunner_RegisterShutdownHook(t *testing.T) {
 	appMock.AssertExpectations(t)
 	loggerMock.AssertExpectations(t)
 }
+
+// onStopped ожидает сообщения о начале и завершении остановки и итоговую строку
+// лога с указанными счетчиками приложений
+func onStopped(m *MockLogger, started, startFailed, stopFailed int) *mock.Call {
+	m.On("Info", "shutdown initiated", "at", mock.Anything).Once()
+	m.On("Info", "shutdown completed", "at", mock.Anything, "duration", mock.Anything).Once()
+
+	return m.On("Info", "application was stopped",
+		"started", started,
+		"start_failed", startFailed,
+		"stop_failed", stopFailed,
+		"duration", mock.Anything,
+	)
+}
+
+// discardLogger логгер, отбрасывающий все сообщения
+type discardLogger struct{}
+
+func (discardLogger) Debug(string, ...any) {}
+func (discardLogger) Error(string, ...any) {}
+func (discardLogger) Info(string, ...any)  {}
+func (discardLogger) Warn(string, ...any)  {}
+
+// allStarted сообщает, что все приложения текущего запуска запущены
+func allStarted(r *Runner) bool {
+	summary := r.Summary()
+	if summary.Status != RunStatusRunning || len(summary.Apps) == 0 {
+		return false
+	}
+
+	for _, a := range summary.Apps {
+		if !a.Started {
+			return false
+		}
+	}
+	return true
+}
+
+// inflightApp считает одновременно выполняющиеся Start
+type inflightApp struct {
+	inflight *atomic.Int32
+	peak     *atomic.Int32
+	started  *atomic.Int32
+}
+
+func (a *inflightApp) Start() error {
+	n := a.inflight.Add(1)
+	for {
+		peak := a.peak.Load()
+		if n <= peak || a.peak.CompareAndSwap(peak, n) {
+			break
+		}
+	}
+
+	time.Sleep(20 * time.Millisecond)
+	a.inflight.Add(-1)
+	a.started.Add(1)
+	return nil
+}
+
+func (a *inflightApp) Stop() error {
+	return nil
+}
+
+func TestAppsRunner_Run_StartConcurrency(t *testing.T) {
+	var inflight, peak, started atomic.Int32
+
+	runner := New(discardLogger{}, WithStartConcurrency(3))
+	for range 10 {
+		runner.RegisterApp(&inflightApp{inflight: &inflight, peak: &peak, started: &started})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	go func() {
+		assert.Eventually(t, func() bool { return started.Load() == 10 }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	err := runner.Run(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int32(10), started.Load())
+	assert.LessOrEqual(t, peak.Load(), int32(3))
+	assert.Positive(t, peak.Load())
+}
+
+func TestAppsRunner_Summary(t *testing.T) {
+	okApp := &MockApp{}
+	badApp := &MockApp{}
+
+	startErr := errors.New("start error")
+	okApp.On("Start").Return(nil)
+	okApp.On("Stop").Return(nil)
+	badApp.On("Start").After(50 * time.Millisecond).Return(startErr)
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("ok", okApp)
+	runner.RegisterNamedApp("bad", badApp)
+
+	assert.Equal(t, RunStatusNotRun, runner.Summary().Status)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, startErr)
+
+	summary := runner.Summary()
+	assert.Equal(t, RunStatusFailed, summary.Status)
+	assert.Equal(t, `app "bad" start: start error`, summary.Error)
+	assert.Positive(t, summary.Duration)
+	require.Len(t, summary.Apps, 2)
+
+	assert.Equal(t, "ok", summary.Apps[0].Name)
+	assert.True(t, summary.Apps[0].Started)
+	assert.Empty(t, summary.Apps[0].StartError)
+
+	assert.Equal(t, "bad", summary.Apps[1].Name)
+	assert.False(t, summary.Apps[1].Started)
+	assert.Equal(t, startErr.Error(), summary.Apps[1].StartError)
+	assert.GreaterOrEqual(t, summary.Apps[1].StartDuration, 50*time.Millisecond)
+
+	okApp.AssertExpectations(t)
+	badApp.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_StopOnStartError(t *testing.T) {
+	appMock := &MockApp{}
+
+	startErr := errors.New("start error")
+	appMock.On("Start").Return(startErr)
+	appMock.On("Stop").Return(nil).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("partial", appMock, WithStopOnStartError())
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, startErr)
+
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Done(t *testing.T) {
+	appMock := &MockApp{}
+
+	stopping := make(chan struct{})
+	release := make(chan struct{})
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Run(func(mock.Arguments) {
+		close(stopping)
+		<-release
+	}).Return(nil)
+
+	runner := New(discardLogger{})
+	runner.RegisterApp(appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+
+	<-stopping
+	select {
+	case <-runner.Done():
+		t.Fatal("Done closed before Stop returned")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	close(release)
+	select {
+	case <-runner.Done():
+	case <-time.After(time.Second):
+		t.Fatal("Done not closed after shutdown")
+	}
+
+	require.NoError(t, <-errCh)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_MaxStartupTime(t *testing.T) {
+	fastApp := &MockApp{}
+	slowApp := &MockApp{}
+
+	// Start медленного приложения не возвращается до завершения теста
+	block := make(chan struct{})
+	defer close(block)
+
+	fastApp.On("Start").Return(nil)
+	fastApp.On("Stop").Return(nil).Once()
+	slowApp.On("Start").Run(func(mock.Arguments) { <-block }).Return(nil)
+
+	runner := New(discardLogger{}, WithMaxStartupTime(50*time.Millisecond))
+	runner.RegisterNamedApp("fast", fastApp)
+	runner.RegisterNamedApp("slow", slowApp)
+
+	startedAt := time.Now()
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrStartupTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+
+	fastApp.AssertExpectations(t)
+}
+
+func TestAppsRunner_Err(t *testing.T) {
+	appMock := &MockApp{}
+
+	startErr := errors.New("start error")
+	appMock.On("Start").Return(startErr)
+
+	runner := New(discardLogger{})
+	runner.RegisterApp(appMock)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(context.Background()) }()
+
+	<-runner.Done()
+	err := <-errCh
+	require.ErrorIs(t, err, startErr)
+	assert.Equal(t, err, runner.Err())
+
+	appMock.AssertExpectations(t)
+}
+
+// callRecorder записывает последовательность вызовов Start/Stop
+type callRecorder struct {
+	mu    sync.Mutex
+	calls []string
+}
+
+func (c *callRecorder) record(call string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.calls = append(c.calls, call)
+}
+
+func (c *callRecorder) Calls() []string {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return slices.Clone(c.calls)
+}
+
+// filter возвращает вызовы с указанным префиксом без префикса
+func (c *callRecorder) filter(prefix string) []string {
+	var names []string
+	for _, call := range c.Calls() {
+		if name, ok := strings.CutPrefix(call, prefix); ok {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// recordingApp приложение, записывающее вызовы в callRecorder. При ненулевом
+// startDelay или stopDelay Start и Stop выполняются указанное время и
+// дополнительно записывают свое завершение.
+type recordingApp struct {
+	name       string
+	recorder   *callRecorder
+	startDelay time.Duration
+	stopDelay  time.Duration
+}
+
+func (a *recordingApp) Start() error {
+	a.recorder.record("start:" + a.name)
+	if a.startDelay > 0 {
+		time.Sleep(a.startDelay)
+		a.recorder.record("started:" + a.name)
+	}
+	return nil
+}
+
+func (a *recordingApp) Stop() error {
+	a.recorder.record("stop:" + a.name)
+	if a.stopDelay > 0 {
+		time.Sleep(a.stopDelay)
+		a.recorder.record("stopped:" + a.name)
+	}
+	return nil
+}
+
+func TestAppsRunner_Run_Priority(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	for _, app := range []struct {
+		name     string
+		priority int
+	}{{"a", 0}, {"b", 10}, {"c", 5}, {"d", 10}} {
+		runner.RegisterNamedApp(app.name, &recordingApp{name: app.name, recorder: recorder}, WithPriority(app.priority))
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// b и d запускаются параллельно, но раньше c, а c — раньше a
+	starts := recorder.filter("start:")
+	require.Len(t, starts, 4)
+	assert.ElementsMatch(t, []string{"b", "d"}, starts[:2])
+	assert.Equal(t, []string{"c", "a"}, starts[2:])
+
+	assert.Equal(t, []string{"a", "c", "d", "b"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_StartErrorWrapped(t *testing.T) {
+	appMock := &MockApp{}
+
+	startErr := errors.New("start error")
+	appMock.On("Start").Return(startErr)
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("db", appMock)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, startErr)
+	assert.Contains(t, err.Error(), `"db"`)
+	assert.Equal(t, startErr, errors.Unwrap(err))
+
+	appMock.AssertExpectations(t)
+}
+
+// MockContextApp — мок приложения, получающего контекст
+type MockContextApp struct {
+	mock.Mock
+}
+
+func (m *MockContextApp) Start(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func (m *MockContextApp) Stop(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestAppsRunner_Run_ContextAppStartCancelled(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	// Start блокируется до отмены контекста и завершается успешно
+	inStart := make(chan struct{})
+	appMock.On("Start", mock.Anything).Run(func(args mock.Arguments) {
+		close(inStart)
+		<-args.Get(0).(context.Context).Done()
+	}).Return(nil)
+	appMock.On("Stop", mock.MatchedBy(func(ctx context.Context) bool {
+		return ctx.Err() == nil
+	})).Return(nil).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("server", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-inStart
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_StartCancelledByShutdown(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	// Start возвращает ошибку контекста после начала остановки
+	inStart := make(chan struct{})
+	appMock.On("Start", mock.Anything).Run(func(args mock.Arguments) {
+		close(inStart)
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled)
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("server", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-inStart
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_UnexpectedStartCancellation(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	// Отмена без начала остановки остается ошибкой
+	appMock.On("Start", mock.Anything).Return(context.Canceled)
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("server", appMock)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, context.Canceled)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_RegisterStartOnly(t *testing.T) {
+	loggerMock := &MockLogger{}
+
+	started := false
+	loggerMock.On("Debug", "start application", "app", "migrations").Once()
+	loggerMock.On("Debug", "application started", "app", "migrations").Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
+
+	runner := New(loggerMock)
+	runner.RegisterStartOnly("migrations", func() error {
+		started = true
+		return nil
+	})
+	runner.RegisterStartOnly("nil", nil)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.True(t, started, "start should be called")
+
+	// Остановка приложения без Stop не логируется
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_RegisterShutdownHook_StopOnly(t *testing.T) {
+	loggerMock := &MockLogger{}
+
+	hookCalled := false
+	loggerMock.On("Debug", "calling shutdown hook", "app", "").Once()
+	onStopped(loggerMock, 0, 0, 0).Once()
+
+	runner := New(loggerMock)
+	runner.RegisterShutdownHook(func() error {
+		hookCalled = true
+		return nil
+	})
+	runner.RegisterShutdownHook(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.True(t, hookCalled, "shutdown hook should be called")
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_TeardownOrder(t *testing.T) {
+	recorder := &callRecorder{}
+	hook := func(name string) callback {
+		return func() error {
+			recorder.record(name)
+			return nil
+		}
+	}
+
+	runner := New(discardLogger{})
+	runner.RegisterPostStopHook(hook("post:metrics"))
+	runner.RegisterShutdownHook(hook("hook:first"))
+	runner.RegisterNamedApp("db", &recordingApp{name: "db", recorder: recorder})
+	runner.RegisterShutdownHook(hook("hook:second"))
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder}, DependsOn("db"))
+	runner.RegisterPostStopHook(hook("post:traces"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Приложения в порядке остановки, затем hooks в обратном порядке
+	// регистрации, затем post-stop hooks в порядке регистрации
+	assert.Equal(t, []string{
+		"start:db", "start:api",
+		"stop:api", "stop:db",
+		"hook:second", "hook:first",
+		"post:metrics", "post:traces",
+	}, recorder.Calls())
+}
+
+func TestAppsRunner_Run_GoroutineTagging(t *testing.T) {
+	loggerMock := &MockLogger{}
+	first := &MockApp{}
+	second := &MockApp{}
+
+	for _, app := range []*MockApp{first, second} {
+		app.On("Start").Return(nil)
+		app.On("Stop").Return(nil)
+	}
+
+	// Номер приложения одинаков во всех сообщениях его жизненного цикла
+	for seq, name := range []string{"first", "second"} {
+		loggerMock.On("Debug", "start application", "app", name, "seq", seq).Once()
+		loggerMock.On("Debug", "application started", "app", name, "seq", seq).Once()
+		loggerMock.On("Debug", "stop application", "app", name, "seq", seq).Once()
+	}
+	onStopped(loggerMock, 2, 0, 0).Once()
+
+	runner := New(loggerMock, WithGoroutineTagging())
+	runner.RegisterNamedApp("first", first)
+	runner.RegisterNamedApp("second", second)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_ParallelStopDiamond(t *testing.T) {
+	recorder := &callRecorder{}
+	app := func(name string) *recordingApp {
+		return &recordingApp{name: name, recorder: recorder, stopDelay: 50 * time.Millisecond}
+	}
+
+	// top зависит от left и right, обе — от base
+	runner := New(discardLogger{}, WithParallelStop(0))
+	runner.RegisterNamedApp("top", app("top"), DependsOn("left", "right"))
+	runner.RegisterNamedApp("left", app("left"), DependsOn("base"))
+	runner.RegisterNamedApp("right", app("right"), DependsOn("base"))
+	runner.RegisterNamedApp("base", app("base"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	calls := recorder.Calls()
+	pos := func(call string) int {
+		i := slices.Index(calls, call)
+		require.GreaterOrEqual(t, i, 0, call)
+		return i
+	}
+
+	// Зависимые останавливаются после завершения Stop тех, кто зависит от них
+	for _, branch := range []string{"left", "right"} {
+		assert.Less(t, pos("stopped:top"), pos("stop:"+branch))
+		assert.Less(t, pos("stopped:"+branch), pos("stop:base"))
+	}
+
+	// Независимые ветви останавливаются параллельно
+	assert.Less(t, pos("stop:left"), pos("stopped:right"))
+	assert.Less(t, pos("stop:right"), pos("stopped:left"))
+}
+
+func TestAppsRunner_Run_PanicHandler(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Run(func(mock.Arguments) { panic("boom") })
+
+	var (
+		gotName      string
+		gotRecovered any
+		gotStack     []byte
+	)
+	runner := New(discardLogger{}, WithPanicHandler(func(appName string, recovered any, stack []byte) {
+		gotName = appName
+		gotRecovered = recovered
+		gotStack = stack
+	}))
+	runner.RegisterNamedApp("faulty", appMock)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrPanic)
+	assert.Contains(t, err.Error(), "boom")
+
+	assert.Equal(t, "faulty", gotName)
+	assert.Equal(t, "boom", gotRecovered)
+	assert.NotEmpty(t, gotStack)
+}
+
+func TestAppsRunner_StopCalledOnce(t *testing.T) {
+	appMock := &MockApp{}
+
+	runner := New(discardLogger{})
+	signals := newFakeSignals(runner)
+
+	// Ошибка запуска и сигнал запускают остановку одновременно
+	startErr := errors.New("start error")
+	appMock.On("Start").Run(func(mock.Arguments) {
+		signals.send(t, syscall.SIGTERM)
+	}).Return(startErr)
+	appMock.On("Stop").Return(nil).Once()
+
+	runner.RegisterNamedApp("partial", appMock, WithStopOnStartError())
+
+	err := runner.Run(context.Background())
+	if err != nil {
+		require.ErrorIs(t, err, startErr)
+	}
+
+	// Повторная остановка тем же путем не вызывает Stop
+	require.NoError(t, runner.stopApp(context.Background(), 0))
+
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_RegisterReadyApp(t *testing.T) {
+	warmingUp := make(chan struct{})
+	stopped := make(chan struct{})
+
+	runner := New(discardLogger{})
+	runner.RegisterReadyApp("server", func(ready chan<- struct{}) error {
+		// Приложение еще не готово, хотя start уже выполняется
+		<-warmingUp
+		close(ready)
+		<-stopped
+		return nil
+	}, func() error {
+		close(stopped)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+
+	select {
+	case <-runner.Ready():
+		t.Fatal("Ready closed before readiness signal")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.Equal(t, StateStarting, runner.State())
+
+	close(warmingUp)
+	select {
+	case <-runner.Ready():
+	case <-time.After(time.Second):
+		t.Fatal("Ready not closed after readiness signal")
+	}
+	assert.True(t, runner.Summary().Apps[0].Started)
+
+	cancel()
+	require.NoError(t, <-errCh)
+}
+
+func TestAppsRunner_RegisterReadyApp_ExitAfterReady(t *testing.T) {
+	serveErr := errors.New("listen error")
+	fail := make(chan struct{})
+
+	runner := New(discardLogger{})
+	runner.RegisterReadyApp("server", func(ready chan<- struct{}) error {
+		ready <- struct{}{}
+		<-fail
+		return serveErr
+	}, nil)
+
+	go func() {
+		<-runner.Ready()
+		close(fail)
+	}()
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, serveErr)
+	assert.EqualError(t, err, `app "server" start: listen error`)
+}
+
+func TestAppsRunner_RegisterReadyApp_StopWaitsForStart(t *testing.T) {
+	recorder := &callRecorder{}
+	stopping := make(chan struct{})
+
+	runner := New(&recordingLogger{recorder: recorder})
+	runner.RegisterReadyApp("server", func(ready chan<- struct{}) error {
+		close(ready)
+		<-stopping
+
+		// start завершается не сразу после Stop
+		time.Sleep(30 * time.Millisecond)
+		recorder.record("returned:server")
+		return nil
+	}, func() error {
+		close(stopping)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	calls := recorder.Calls()
+	returned := slices.Index(calls, "returned:server")
+	require.NotEqual(t, -1, returned)
+	assert.Less(t, returned, slices.Index(calls, "info:application was stopped"))
+
+	// Время остановки включает завершение start
+	assert.GreaterOrEqual(t, runner.Timings().Apps[0].Stop, 30*time.Millisecond)
+}
+
+func TestAppsRunner_RegisterReadyApp_ShutdownBeforeReady(t *testing.T) {
+	started := make(chan struct{})
+	stopping := make(chan struct{})
+	var stopped atomic.Bool
+
+	runner := New(discardLogger{})
+	runner.RegisterReadyApp("server", func(chan<- struct{}) error {
+		// Остановка начинается до сигнала готовности
+		close(started)
+		<-stopping
+		return nil
+	}, func() error {
+		stopped.Store(true)
+		close(stopping)
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-started
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		close(stopping)
+		t.Fatal("Run did not return after shutdown started before ready")
+	}
+	assert.True(t, stopped.Load())
+}
+
+func TestAppsRunner_Run_Messages(t *testing.T) {
+	loggerMock := &MockLogger{}
+	appMock := &MockApp{}
+
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	// Переопределенный ключ применяется во всех сообщениях, остальные сохраняют значения по умолчанию
+	loggerMock.On("Debug", "start application", "service", "api").Once()
+	loggerMock.On("Debug", "application started", "service", "api").Once()
+	loggerMock.On("Debug", "stop application", "service", "api").Once()
+	loggerMock.On("Info", "shutdown initiated", "initiated_at", mock.Anything).Once()
+	loggerMock.On("Info", "shutdown completed", "initiated_at", mock.Anything, "elapsed", mock.Anything).Once()
+	loggerMock.On("Info", "приложения остановлены",
+		"running", 1, "start_failed", 0, "stop_failed", 0, "elapsed", mock.Anything).Once()
+
+	runner := New(loggerMock, WithMessages(Messages{
+		AppKey:             "service",
+		AtKey:              "initiated_at",
+		DurationKey:        "elapsed",
+		StartedKey:         "running",
+		ApplicationStopped: "приложения остановлены",
+	}))
+	runner.RegisterNamedApp("api", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	loggerMock.AssertExpectations(t)
+	appMock.AssertExpectations(t)
+}
+
+type MockDrainerApp struct {
+	MockApp
+}
+
+func (m *MockDrainerApp) Drain(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestAppsRunner_Run_Drainer(t *testing.T) {
+	appMock := &MockDrainerApp{}
+
+	appMock.On("Start").Return(nil)
+	appMock.On("Drain", mock.MatchedBy(func(ctx context.Context) bool {
+		_, ok := ctx.Deadline()
+		return ok
+	})).Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(discardLogger{}, WithShutdownTimeout(time.Second))
+	runner.RegisterNamedApp("http", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Drain вызывается до Stop
+	var calls []string
+	for _, call := range appMock.Calls {
+		calls = append(calls, call.Method)
+	}
+	assert.Equal(t, []string{"Start", "Drain", "Stop"}, calls)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_DrainTimeout(t *testing.T) {
+	recorder := &callRecorder{}
+	release := make(chan struct{})
+	defer close(release)
+
+	appMock := &MockDrainerApp{}
+	appMock.On("Start").Return(nil)
+	// Drain не отслеживает контекст и не завершается сам
+	appMock.On("Drain", mock.Anything).Run(func(mock.Arguments) { <-release }).Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(&recordingLogger{recorder: recorder}, WithDrainTimeout(50*time.Millisecond), WithShutdownTimeout(time.Second))
+	runner.RegisterNamedApp("http", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	start := time.Now()
+	require.NoError(t, runner.Run(ctx))
+
+	// Stop вызывается после истечения времени Drain, не дожидаясь его возврата
+	assert.Less(t, time.Since(start), time.Second)
+	assert.Contains(t, recorder.filter("warn:"), "application drain timeout exceeded")
+	appMock.AssertCalled(t, "Stop")
+}
+
+func TestAppsRunner_Run_DrainTimeoutSeveralDrainers(t *testing.T) {
+	runner := New(discardLogger{}, WithDrainTimeout(100*time.Millisecond), WithShutdownTimeout(100*time.Millisecond))
+
+	var apps []*MockDrainerApp
+	for _, name := range []string{"a", "b", "c"} {
+		appMock := &MockDrainerApp{}
+		appMock.On("Start").Return(nil)
+		// Drain каждого приложения занимает все отведенное на этап время
+		appMock.On("Drain", mock.Anything).Run(func(args mock.Arguments) {
+			<-args.Get(0).(context.Context).Done()
+		}).Return(context.Canceled)
+		appMock.On("Stop").Return(nil)
+
+		runner.RegisterNamedApp(name, appMock)
+		apps = append(apps, appMock)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	// Drain выполняются одним этапом и не расходуют время остановки
+	start := time.Now()
+	require.NoError(t, runner.Run(ctx))
+	assert.Less(t, time.Since(start), 250*time.Millisecond)
+	for _, appMock := range apps {
+		appMock.AssertNumberOfCalls(t, "Drain", 1)
+		appMock.AssertCalled(t, "Stop")
+	}
+}
+
+func TestAppsRunner_Run_DrainTimeoutStartIgnoresContext(t *testing.T) {
+	inStart := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	// Start не отслеживает контекст и не возвращается до конца теста
+	appMock := &MockContextApp{}
+	appMock.On("Start", mock.Anything).Run(func(mock.Arguments) {
+		close(inStart)
+		<-release
+	}).Return(nil)
+
+	runner := New(discardLogger{}, WithDrainTimeout(100*time.Millisecond), WithShutdownTimeout(100*time.Millisecond))
+	runner.RegisterContextApp("worker", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-inStart
+		cancel()
+	}()
+
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	select {
+	case err := <-done:
+		require.ErrorIs(t, err, ErrShutdownTimeout)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not return while Start ignored the context")
+	}
+}
+
+type MockAsyncApp struct {
+	mock.Mock
+}
+
+func (m *MockAsyncApp) Start() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func (m *MockAsyncApp) Stop() (<-chan error, error) {
+	args := m.Called()
+	return args.Get(0).(<-chan error), args.Error(1)
+}
+
+func TestAppsRunner_Run_AsyncStopper(t *testing.T) {
+	var cleaned atomic.Bool
+	done := make(chan error, 1)
+
+	appMock := &MockAsyncApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Run(func(mock.Arguments) {
+		go func() {
+			time.Sleep(50 * time.Millisecond)
+			cleaned.Store(true)
+			done <- nil
+		}()
+	}).Return((<-chan error)(done), nil)
+
+	runner := New(discardLogger{}, WithShutdownTimeout(time.Second))
+	runner.RegisterAsyncApp("worker", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Run завершается только после результата асинхронной остановки
+	assert.True(t, cleaned.Load())
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_SequentialStart(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithSequentialStart())
+	// Приложение без Stop тоже перестает учитываться после остановки
+	runner.RegisterStartOnly("first", func() error { return nil })
+	for _, name := range []string{"a", "b", "c"} {
+		runner.RegisterNamedApp(name, &recordingApp{name: name, recorder: recorder, startDelay: 20 * time.Millisecond})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Следующий Start вызывается только после завершения предыдущего
+	var starts []string
+	for _, call := range recorder.Calls() {
+		if strings.HasPrefix(call, "start") {
+			starts = append(starts, call)
+		}
+	}
+	assert.Equal(t, []string{
+		"start:a", "started:a",
+		"start:b", "started:b",
+		"start:c", "started:c",
+	}, starts)
+
+	assert.Equal(t, []string{"c", "b", "a"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_HookTimeout(t *testing.T) {
+	loggerMock := &MockLogger{}
+
+	// Hook зависает до завершения теста
+	block := make(chan struct{})
+	defer close(block)
+
+	loggerMock.On("Debug", "calling shutdown hook", "app", "").Once()
+	loggerMock.On("Error", "shutdown hook timeout exceeded", "app", "", "timeout", 50*time.Millisecond).Once()
+	loggerMock.On("Error", "terminating with error", "error", ErrShutdownTimeout).Once()
+	onStopped(loggerMock, 0, 0, 0).Once()
+
+	runner := New(loggerMock, WithHookTimeout(50*time.Millisecond))
+	runner.RegisterShutdownHook(func() error {
+		<-block
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	startedAt := time.Now()
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+
+	loggerMock.AssertExpectations(t)
+}
+
+// cache объект с несколькими ролями жизненного цикла
+type cache struct {
+	recorder *callRecorder
+}
+
+func (c *cache) Warm() error {
+	c.recorder.record("warm")
+	return nil
+}
+
+func (c *cache) Open() error {
+	c.recorder.record("open")
+	return nil
+}
+
+func (c *cache) Flush() error {
+	c.recorder.record("flush")
+	return nil
+}
+
+func TestAppsRunner_RegisterStartStop(t *testing.T) {
+	recorder := &callRecorder{}
+	c := &cache{recorder: recorder}
+
+	runner := New(discardLogger{})
+	runner.RegisterStartOnly("cache-warmer", c.Warm, WithPriority(1))
+	runner.RegisterStartStop("cache", c.Open, c.Flush)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"warm", "open", "flush"}, recorder.Calls())
+
+	summary := runner.Summary()
+	require.Len(t, summary.Apps, 2)
+	assert.Equal(t, "cache-warmer", summary.Apps[0].Name)
+	assert.Equal(t, "cache", summary.Apps[1].Name)
+}
+
+func TestAppsRunner_RegisterAppWithCleanup(t *testing.T) {
+	recorder := &callRecorder{}
+	start := func(name string, err error) func(ctx context.Context) (func() error, error) {
+		return func(context.Context) (func() error, error) {
+			recorder.record("start:" + name)
+			return func() error {
+				recorder.record("cleanup:" + name)
+				return nil
+			}, err
+		}
+	}
+
+	runner := New(discardLogger{})
+	runner.RegisterAppWithCleanup("db", start("db", nil))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"start:db", "cleanup:db"}, recorder.Calls())
+
+	// При ошибке Start функция освобождения не вызывается
+	recorder = &callRecorder{}
+	startErr := errors.New("connection refused")
+
+	runner = New(discardLogger{})
+	runner.RegisterAppWithCleanup("db", start("db", startErr), WithStopOnStartError())
+
+	require.ErrorIs(t, runner.Run(context.Background()), startErr)
+	assert.Equal(t, []string{"start:db"}, recorder.Calls())
+}
+
+func TestAppsRunner_Run_ShutdownOnAppExit(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(nil).Once()
+
+	exit := make(chan struct{})
+
+	runner := New(discardLogger{}, WithShutdownOnAppExit())
+	runner.RegisterNamedApp("api", appMock)
+	runner.RegisterReadyApp("worker", func(ready chan<- struct{}) error {
+		close(ready)
+		// Приложение завершается само, без ошибки
+		<-exit
+		return nil
+	}, nil)
+
+	go func() {
+		<-runner.Ready()
+		close(exit)
+	}()
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	require.NoError(t, runner.Run(ctx))
+	require.NoError(t, ctx.Err(), "run stopped by timeout instead of app exit")
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_TolerateLateFailures(t *testing.T) {
+	recorder := &callRecorder{}
+	fail := make(chan struct{})
+
+	runner := New(discardLogger{}, WithTolerateLateFailures())
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	runner.RegisterReadyApp("worker", func(ready chan<- struct{}) error {
+		close(ready)
+		<-fail
+		return errors.New("queue closed")
+	}, func() error {
+		recorder.record("stop:worker")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		close(fail)
+
+		// Отказавшее приложение остановлено, остальные продолжают работу
+		assert.Eventually(t, func() bool { return len(recorder.filter("stop:worker")) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, StateRunning, runner.State())
+		assert.Equal(t, 1, runner.RunningCount())
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"start:api", "stop:worker", "stop:api"}, recorder.Calls())
+}
+
+type MockCloser struct {
+	mock.Mock
+}
+
+func (m *MockCloser) Close() error {
+	args := m.Called()
+	return args.Error(0)
+}
+
+func TestAppsRunner_RegisterCloser(t *testing.T) {
+	closeErr := errors.New("connection reset")
+	closerMock := &MockCloser{}
+	closerMock.On("Close").Return(closeErr).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterCloser("db", closerMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, closeErr)
+	assert.EqualError(t, err, `close "db": connection reset`)
+
+	closerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_RegisterApps(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithSequentialStart())
+	runner.RegisterApps(
+		&recordingApp{name: "a", recorder: recorder},
+		&recordingApp{name: "b", recorder: recorder},
+	)
+	runner.RegisterNamedApps(map[string]app{
+		"d": &recordingApp{name: "d", recorder: recorder},
+		"c": &recordingApp{name: "c", recorder: recorder},
+	})
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"#0", "#1", "c", "d"}}, plan.Start)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"a", "b", "c", "d"}, recorder.filter("start:"))
+}
+
+func TestAppsRunner_RegisterApps_Nil(t *testing.T) {
+	runner := New(discardLogger{})
+
+	assert.PanicsWithValue(t, "go_runner: nil app", func() {
+		runner.RegisterApps(&MockApp{}, nil)
+	})
+	assert.PanicsWithValue(t, `go_runner: nil app "api"`, func() {
+		runner.RegisterNamedApps(map[string]app{"api": nil})
+	})
+}
+
+func TestAppsRunner_RegisterNamedApp_TypedNil(t *testing.T) {
+	runner := New(discardLogger{})
+
+	// nil указатель в непустом интерфейсе обнаруживается при регистрации
+	var appMock *MockApp
+	assert.PanicsWithValue(t, `go_runner: nil app "api"`, func() {
+		runner.RegisterNamedApp("api", appMock)
+	})
+
+	var contextApp *MockContextApp
+	assert.PanicsWithValue(t, `go_runner: nil app "worker"`, func() {
+		runner.RegisterContextApp("worker", contextApp)
+	})
+
+	assert.Empty(t, runner.apps)
+}
+
+type MockActor struct {
+	mock.Mock
+	interrupt chan struct{}
+}
+
+func (m *MockActor) Execute() error {
+	m.Called()
+	<-m.interrupt
+	return errors.New("interrupted")
+}
+
+func (m *MockActor) Interrupt(err error) {
+	m.Called(err)
+	close(m.interrupt)
+}
+
+func TestAppsRunner_RegisterActor(t *testing.T) {
+	trigger := make(chan struct{})
+
+	// Остановка запускается, когда Execute уже выполняется
+	actor := &MockActor{interrupt: make(chan struct{})}
+	actor.On("Execute").Run(func(mock.Arguments) { close(trigger) }).Once()
+	actor.On("Interrupt", ErrTriggered).Once()
+
+	runner := New(discardLogger{}, WithTriggerChannel(trigger))
+	runner.RegisterActor("worker", actor)
+
+	require.NoError(t, runner.Run(context.Background()))
+	actor.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_ParallelStopLimit(t *testing.T) {
+	recorder := &callRecorder{}
+	app := func(name string) *recordingApp {
+		return &recordingApp{name: name, recorder: recorder, stopDelay: 30 * time.Millisecond}
+	}
+
+	// Четыре приложения одного уровня зависят от base
+	runner := New(discardLogger{}, WithParallelStop(2))
+	workers := []string{"w1", "w2", "w3", "w4"}
+	for _, name := range workers {
+		runner.RegisterNamedApp(name, app(name), DependsOn("base"))
+	}
+	runner.RegisterNamedApp("base", app("base"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Число одновременно выполняющихся Stop не превышает лимит
+	var inflight, peak int
+	calls := recorder.Calls()
+	for _, call := range calls {
+		switch {
+		case strings.HasPrefix(call, "stop:"):
+			inflight++
+			peak = max(peak, inflight)
+		case strings.HasPrefix(call, "stopped:"):
+			inflight--
+		}
+	}
+	assert.Equal(t, 2, peak)
+
+	// base останавливается после всех приложений уровня
+	base := slices.Index(calls, "stop:base")
+	for _, name := range workers {
+		assert.Less(t, slices.Index(calls, "stopped:"+name), base)
+	}
+}
+
+func TestAppsRunner_Run_ParallelStopGlobalLimit(t *testing.T) {
+	recorder := &callRecorder{}
+
+	// Без зависимостей все приложения находятся на одном уровне
+	runner := New(discardLogger{}, WithParallelStop(3))
+	names := []string{"a", "b", "c", "d", "e", "f", "g"}
+	for _, name := range names {
+		runner.RegisterNamedApp(name, &recordingApp{name: name, recorder: recorder, stopDelay: 20 * time.Millisecond})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	var inflight, peak int
+	for _, call := range recorder.Calls() {
+		switch {
+		case strings.HasPrefix(call, "stop:"):
+			inflight++
+			peak = max(peak, inflight)
+		case strings.HasPrefix(call, "stopped:"):
+			inflight--
+		}
+	}
+	assert.Equal(t, 3, peak)
+	assert.ElementsMatch(t, names, recorder.filter("stopped:"))
+}
+
+func TestAppsRunner_Run_AutoShutdownBudget(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	stopDeadline := make(chan time.Duration, 1)
+	appMock.On("Start", mock.Anything).Return(nil)
+	appMock.On("Stop", mock.Anything).Run(func(args mock.Arguments) {
+		deadline, ok := args.Get(0).(context.Context).Deadline()
+		require.True(t, ok)
+		stopDeadline <- time.Until(deadline)
+	}).Return(nil)
+
+	runner := New(discardLogger{}, WithAutoShutdownBudget(0.5))
+	runner.RegisterContextApp("api", appMock)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 400*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Остановка начинается заранее и получает зарезервированную половину времени
+	require.NoError(t, ctx.Err(), "shutdown started after the run deadline")
+	remaining := <-stopDeadline
+	assert.Greater(t, remaining, 100*time.Millisecond)
+	assert.LessOrEqual(t, remaining, 200*time.Millisecond)
+
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_StopPanicContinues(t *testing.T) {
+	recorder := &callRecorder{}
+	panicking := &MockApp{}
+	panicking.On("Start").Return(nil)
+	panicking.On("Stop").Run(func(mock.Arguments) { panic("stop boom") })
+
+	// Остановка выполняется в обратном порядке, поэтому паникующий Stop вызывается первым
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("a", &recordingApp{name: "a", recorder: recorder})
+	runner.RegisterNamedApp("b", &recordingApp{name: "b", recorder: recorder})
+	runner.RegisterNamedApp("c", panicking)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, ErrPanic)
+	assert.Equal(t, []string{"b", "a"}, recorder.filter("stop:"))
+	assert.Equal(t, "application panic: stop boom", runner.Summary().Apps[2].StopError)
+
+	panicking.AssertExpectations(t)
+}
+
+type MockWarmerApp struct {
+	MockApp
+}
+
+func (m *MockWarmerApp) Warmup(ctx context.Context) error {
+	args := m.Called(ctx)
+	return args.Error(0)
+}
+
+func TestAppsRunner_Run_Warmup(t *testing.T) {
+	appMock := &MockWarmerApp{}
+
+	warming := make(chan struct{})
+	release := make(chan struct{})
+	appMock.On("Start").Return(nil)
+	appMock.On("Warmup", mock.Anything).Run(func(mock.Arguments) {
+		close(warming)
+		<-release
+	}).Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("cache", appMock)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(ctx) }()
+
+	// Пока идет прогрев, приложение не считается запущенным
+	<-warming
+	select {
+	case <-runner.Ready():
+		t.Fatal("Ready closed during warmup")
+	case <-time.After(50 * time.Millisecond):
+	}
+	assert.False(t, allStarted(runner))
+
+	close(release)
+	<-runner.Ready()
+	cancel()
+
+	require.NoError(t, <-errCh)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_WarmupError(t *testing.T) {
+	appMock := &MockWarmerApp{}
+
+	warmupErr := errors.New("cache unavailable")
+	appMock.On("Start").Return(nil)
+	appMock.On("Warmup", mock.Anything).Return(warmupErr)
+	// Start завершился успешно, поэтому Stop освобождает ресурсы
+	appMock.On("Stop").Return(nil).Once()
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("cache", appMock)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, warmupErr)
+	assert.EqualError(t, err, `app "cache" start: warmup: cache unavailable`)
+
+	appMock.AssertExpectations(t)
+}
+
+// syncLogger логгер с буфером, записывающий итоговое сообщение и сброс буфера
+type syncLogger struct {
+	discardLogger
+	recorder *callRecorder
+}
+
+func (l *syncLogger) Info(msg string, _ ...any) {
+	l.recorder.record("info:" + msg)
+}
+
+func (l *syncLogger) Sync() error {
+	l.recorder.record("sync")
+	return nil
+}
+
+func TestAppsRunner_Run_LoggerSync(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(&syncLogger{recorder: recorder})
+	runner.RegisterApp(&recordingApp{name: "api", recorder: recorder})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Буфер сбрасывается один раз, после итогового сообщения
+	calls := recorder.Calls()
+	assert.Equal(t, []string{"info:application was stopped", "sync"}, calls[len(calls)-2:])
+	assert.Len(t, recorder.filter("sync"), 1)
+}
+
+// recordingLogger записывает сообщения уровней Debug, Info и Warn в callRecorder
+type recordingLogger struct {
+	discardLogger
+	recorder *callRecorder
+}
+
+func (l *recordingLogger) Debug(msg string, _ ...any) {
+	l.recorder.record("debug:" + msg)
+}
+
+func (l *recordingLogger) Info(msg string, _ ...any) {
+	l.recorder.record("info:" + msg)
+}
+
+func (l *recordingLogger) Warn(msg string, _ ...any) {
+	l.recorder.record("warn:" + msg)
+}
+
+// fieldsLogger записывает сообщения уровней Info и Warn вместе с полями
+type fieldsLogger struct {
+	recordingLogger
+}
+
+func (l *fieldsLogger) Info(msg string, args ...any) {
+	l.recorder.record(strings.TrimSpace(fmt.Sprintln(append([]any{"info:" + msg}, args...)...)))
+}
+
+func (l *fieldsLogger) Warn(msg string, args ...any) {
+	l.recorder.record(strings.TrimSpace(fmt.Sprintln(append([]any{"warn:" + msg}, args...)...)))
+}
+
+func TestAppsRunner_Run_StartCircuitBreaker(t *testing.T) {
+	recorder := &callRecorder{}
+
+	// Start падает пять раз подряд, затем зависимость становится доступна
+	var attempts []time.Time
+	start := func() error {
+		attempts = append(attempts, time.Now())
+		if len(attempts) <= 5 {
+			return errors.New("dependency down")
+		}
+		return nil
+	}
+
+	runner := New(&recordingLogger{recorder: recorder})
+	runner.RegisterStartOnly("client", start, WithStartCircuitBreaker(CircuitBreaker{
+		Threshold:  2,
+		RetryDelay: time.Millisecond,
+		Cooldown:   40 * time.Millisecond,
+	}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, 2*time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	require.Len(t, attempts, 6)
+
+	// После двух неудач выключатель размыкается, каждая неудачная пробная попытка
+	// размыкает его снова
+	assert.Len(t, recorder.filter("warn:start circuit breaker opened"), 4)
+	assert.Len(t, recorder.filter("debug:start circuit breaker half-open"), 4)
+
+	// Пока выключатель разомкнут, попытки не выполняются
+	assert.Less(t, attempts[1].Sub(attempts[0]), 40*time.Millisecond)
+	for i := 2; i < len(attempts); i++ {
+		assert.GreaterOrEqual(t, attempts[i].Sub(attempts[i-1]), 40*time.Millisecond)
+	}
+}
+
+func TestAppsRunner_Run_StartCircuitBreakerDefaults(t *testing.T) {
+	attempts := 0
+	runner := New(discardLogger{})
+	runner.RegisterStartOnly("client", func() error {
+		attempts++
+		return errors.New("dependency down")
+	}, WithStartCircuitBreaker(CircuitBreaker{}))
+
+	// Без RetryDelay попытки выполняются с паузой по умолчанию, а не в цикле
+	ctx, cancel := context.WithTimeout(context.Background(), 250*time.Millisecond)
+	defer cancel()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.LessOrEqual(t, attempts, 4)
+}
+
+func TestRunner_SetLogger(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(nil)
+	runner.SetLogger(&recordingLogger{recorder: recorder})
+	runner.RegisterStartOnly("api", func() error { return nil })
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"start application", "application started"}, recorder.filter("debug:")[:2])
+
+	// После запуска логгер заменить нельзя
+	assert.PanicsWithValue(t, "go_runner: SetLogger called after Run", func() {
+		runner.SetLogger(nil)
+	})
+}
+
+func TestRunner_SetLoggerNil(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.SetLogger(nil)
+	assert.Equal(t, NopLogger{}, runner.logger)
+
+	assert.Equal(t, NopLogger{}, New(nil).logger)
+}
+
+func TestAppsRunner_Run_WithVerbosity(t *testing.T) {
+	run := func(opts ...Option) []string {
+		recorder := &callRecorder{}
+
+		runner := New(&recordingLogger{recorder: recorder}, opts...)
+		runner.RegisterStartOnly("api", func() error { return nil })
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-runner.Ready()
+			cancel()
+		}()
+
+		require.NoError(t, runner.Run(ctx))
+		return recorder.Calls()
+	}
+
+	calls := run()
+	assert.Contains(t, calls, "debug:start application")
+	assert.Contains(t, calls, "info:shutdown initiated")
+
+	calls = run(WithVerbosity(VerbosityVerbose))
+	assert.Contains(t, calls, "info:start application")
+	assert.NotContains(t, calls, "debug:start application")
+
+	// В тихом режиме остается только итоговая строка
+	assert.Equal(t, []string{"info:application was stopped"}, run(WithVerbosity(VerbosityQuiet)))
+}
+
+func TestAppsRunner_RegisterNamedApp_Enabled(t *testing.T) {
+	recorder := &callRecorder{}
+	app := func(name string) *recordingApp {
+		return &recordingApp{name: name, recorder: recorder}
+	}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", app("api"), WithEnabled(true))
+	runner.RegisterNamedApp("metrics", app("metrics"), WithEnabled(false))
+	runner.RegisterApp(app("worker"))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Отключенное приложение не запускается и не останавливается
+	assert.ElementsMatch(t, []string{"api", "worker"}, recorder.filter("start:"))
+	assert.ElementsMatch(t, []string{"api", "worker"}, recorder.filter("stop:"))
+	assert.Len(t, runner.Summary().Apps, 2)
+}
+
+func TestAppsRunner_RegisterNamedApp_WithName(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", &MockApp{}, WithName("public-api"))
+	runner.RegisterApp(&MockApp{}, WithName("db"))
+	runner.RegisterNamedApp("worker", &MockApp{}, DependsOn("public-api", "db"))
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"public-api", "db"}, {"worker"}}, plan.Start)
+}
+
+func TestAppsRunner_Run_ShutdownEvents(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(&recordingLogger{recorder: recorder}, WithDrainDelay(10*time.Millisecond))
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	runner.RegisterShutdownHook(func() error {
+		recorder.record("hook")
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Начало остановки фиксируется до паузы и Stop, завершение — после hooks
+	calls := recorder.Calls()
+	initiated := slices.Index(calls, "info:shutdown initiated")
+	completed := slices.Index(calls, "info:shutdown completed")
+	require.NotEqual(t, -1, initiated)
+	assert.Less(t, initiated, slices.Index(calls, "debug:draining before stop"))
+	assert.Less(t, initiated, slices.Index(calls, "stop:api"))
+	assert.Less(t, slices.Index(calls, "hook"), completed)
+	assert.Less(t, completed, slices.Index(calls, "info:application was stopped"))
+}
+
+func TestAppsRunner_Run_StopOrder(t *testing.T) {
+	recorder := &callRecorder{}
+	app := func(name string) *recordingApp {
+		return &recordingApp{name: name, recorder: recorder}
+	}
+
+	// Все приложения запускаются одновременно, а останавливаются по приоритету остановки
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("db", app("db"), WithStopOrder(1))
+	runner.RegisterNamedApp("api", app("api"), WithStopOrder(3))
+	runner.RegisterNamedApp("queue", app("queue"), WithStopOrder(2))
+	runner.RegisterNamedApp("metrics", app("metrics"))
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, [][]string{{"db", "api", "queue", "metrics"}}, plan.Start)
+	assert.Equal(t, []string{"api", "queue", "db", "metrics"}, plan.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"api", "queue", "db", "metrics"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_ContextAlreadyCancelled(t *testing.T) {
+	appMock := &MockApp{}
+	hookCalled := false
+
+	runner := New(discardLogger{})
+	runner.RegisterApp(appMock)
+	runner.RegisterShutdownHook(func() error {
+		hookCalled = true
+		return nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	// Ни Start, ни Stop, ни hooks не вызываются
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, ErrContextAlreadyCancelled)
+	require.ErrorIs(t, err, context.Canceled)
+	assert.False(t, hookCalled)
+	assert.Equal(t, StateStopped, runner.State())
+	appMock.AssertNotCalled(t, "Start")
+}
+
+// hangInStop блокирует Stop до закрытия block
+func hangInStop(block <-chan struct{}) error {
+	<-block
+	return nil
+}
+
+func TestAppsRunner_Run_StackDumpOnTimeout(t *testing.T) {
+	block := make(chan struct{})
+	defer close(block)
+
+	var dump bytes.Buffer
+	runner := New(discardLogger{}, WithShutdownTimeout(50*time.Millisecond), WithStackDumpOnTimeout(&dump))
+	runner.RegisterStartStop("api", func() error { return nil }, func() error {
+		return hangInStop(block)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.ErrorIs(t, runner.Run(ctx), ErrShutdownTimeout)
+
+	// Дамп содержит горутину зависшего Stop
+	assert.Contains(t, dump.String(), "go-runner.hangInStop(")
+}
+
+func TestAppsRunner_Stopping(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(&recordingLogger{recorder: recorder}, WithDrainDelay(30*time.Millisecond))
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+
+	go func() {
+		<-runner.Stopping()
+		recorder.record("stopping")
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		select {
+		case <-runner.Stopping():
+			t.Error("Stopping closed before shutdown")
+		default:
+		}
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Канал закрывается после начала остановки, но до Stop
+	calls := recorder.Calls()
+	stopping := slices.Index(calls, "stopping")
+	require.NotEqual(t, -1, stopping)
+	assert.Less(t, slices.Index(calls, "info:shutdown initiated"), stopping)
+	assert.Less(t, stopping, slices.Index(calls, "stop:api"))
+}
+
+func TestAppsRunner_Stopping_ContextAlive(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithDrainDelay(30*time.Millisecond))
+	runner.RegisterAppWithCleanup("worker", func(ctx context.Context) (func() error, error) {
+		go func() {
+			<-runner.Stopping()
+			if ctx.Err() == nil {
+				recorder.record("stopping")
+			}
+		}()
+
+		return func() error {
+			if ctx.Err() != nil {
+				recorder.record("stop")
+			}
+			return nil
+		}, nil
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Контекст приложения отменяется после паузы перед остановкой, а не вместе со Stopping
+	assert.Equal(t, []string{"stopping", "stop"}, recorder.Calls())
+}
+
+func TestAppsRunner_RunningCount(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithSequentialStart())
+	for _, name := range []string{"a", "b", "c"} {
+		runner.RegisterNamedApp(name, &recordingApp{
+			name:       name,
+			recorder:   recorder,
+			startDelay: 10 * time.Millisecond,
+			stopDelay:  10 * time.Millisecond,
+		})
+	}
+
+	// Приложение без Stop останавливается первым и тоже перестает учитываться
+	runner.RegisterStartOnly("start-only", func() error {
+		time.Sleep(10 * time.Millisecond)
+		return nil
+	})
+
+	// Опрашиваем счетчик на протяжении всего запуска
+	var observed []int
+	polled := make(chan struct{})
+	go func() {
+		defer close(polled)
+		for {
+			select {
+			case <-runner.Done():
+				return
+			default:
+				if n := runner.RunningCount(); n > 0 && (len(observed) == 0 || observed[len(observed)-1] != n) {
+					observed = append(observed, n)
+				}
+				time.Sleep(time.Millisecond)
+			}
+		}
+	}()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		assert.Equal(t, 4, runner.RunningCount())
+		time.Sleep(10 * time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	<-polled
+
+	// Счетчик растет до числа приложений и падает до нуля по одному
+	assert.Equal(t, []int{1, 2, 3, 4, 3, 2, 1}, observed)
+	assert.Zero(t, runner.RunningCount())
+	assert.Equal(t, appHealth{Name: "start-only", State: "stopped"}, runner.health().Apps[3])
+}
+
+// panicInStart паникует в Start приложения
+func panicInStart() error {
+	panic("boom")
+}
+
+func TestAppsRunner_Run_WithRecover(t *testing.T) {
+	runner := New(discardLogger{}, WithRecover(true))
+	runner.RegisterStartOnly("api", panicInStart)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrPanic)
+	assert.EqualError(t, err, `app "api" start: application panic: boom`)
+}
+
+func TestAppsRunner_Run_WithoutRecover(t *testing.T) {
+	// Паника завершает процесс, поэтому Run выполняется в дочернем процессе теста
+	if os.Getenv("GO_RUNNER_PANIC") == "1" {
+		runner := New(discardLogger{}, WithRecover(false))
+		runner.RegisterStartOnly("api", panicInStart)
+		_ = runner.Run(context.Background())
+		return
+	}
+
+	cmd := exec.Command(os.Args[0], "-test.run=^TestAppsRunner_Run_WithoutRecover$")
+	cmd.Env = append(os.Environ(), "GO_RUNNER_PANIC=1")
+	out, err := cmd.CombinedOutput()
+
+	var exitErr *exec.ExitError
+	require.ErrorAs(t, err, &exitErr)
+	assert.Contains(t, string(out), "panic: boom")
+	assert.Contains(t, string(out), "go-runner.panicInStart(")
+}
+
+func TestRunApp(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil).Once()
+	appMock.On("Stop").Return(nil).Once()
+
+	// Опция сохраняет созданный Runner, чтобы дождаться запуска приложения
+	ready := make(chan (<-chan struct{}), 1)
+	capture := func(r *Runner) { ready <- r.Ready() }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-<-ready
+		cancel()
+	}()
+
+	require.NoError(t, RunApp(ctx, discardLogger{}, appMock, capture))
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_StopOrdering(t *testing.T) {
+	for _, tt := range []struct {
+		name     string
+		ordering StopOrdering
+		want     []string
+	}{
+		{name: "reverse", ordering: StopReverse, want: []string{"c", "b", "a"}},
+		{name: "forward", ordering: StopForward, want: []string{"a", "b", "c"}},
+		{name: "priority", ordering: StopPriority, want: []string{"b", "a", "c"}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := &callRecorder{}
+			app := func(name string) *recordingApp {
+				return &recordingApp{name: name, recorder: recorder}
+			}
+
+			// Приоритеты остановки учитываются только в режиме StopPriority
+			runner := New(discardLogger{}, WithStopOrdering(tt.ordering))
+			runner.RegisterNamedApp("a", app("a"), WithStopOrder(1))
+			runner.RegisterNamedApp("b", app("b"), DependsOn("a"), WithStopOrder(2))
+			runner.RegisterNamedApp("c", app("c"), DependsOn("b"))
+
+			ctx, cancel := context.WithCancel(context.Background())
+			go func() {
+				assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+				cancel()
+			}()
+
+			require.NoError(t, runner.Run(ctx))
+			assert.Equal(t, []string{"a", "b", "c"}, recorder.filter("start:"))
+			assert.Equal(t, tt.want, recorder.filter("stop:"))
+		})
+	}
+}
+
+func TestAppsRunner_Run_StopReverseReady(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithStopOrdering(StopReverseReady))
+	runner.RegisterNamedApp("slow", &recordingApp{name: "slow", recorder: recorder, startDelay: 40 * time.Millisecond})
+	runner.RegisterNamedApp("fast", &recordingApp{name: "fast", recorder: recorder, startDelay: 5 * time.Millisecond})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// fast зарегистрирован позже, но готов раньше, поэтому останавливается последним
+	assert.Equal(t, []string{"fast", "slow"}, recorder.filter("started:"))
+	assert.Equal(t, []string{"slow", "fast"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_Run_CancelCauseLogging(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(&fieldsLogger{recordingLogger{recorder: recorder}}, WithContextCancelCauseLogging())
+	runner.RegisterStartOnly("db", func() error { return errors.New("connection refused") })
+
+	require.Error(t, runner.Run(context.Background()))
+
+	// Причина с именем приложения логируется одной строкой в начале остановки
+	initiated := recorder.filter("info:shutdown initiated")
+	require.Len(t, initiated, 1)
+	assert.Contains(t, initiated[0], `cause application start failed: app "db" start: connection refused`)
+}
+
+func TestRunner_Reset(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	runner.RegisterShutdownHook(func() error {
+		recorder.record("hook")
+		return nil
+	})
+
+	run := func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			<-runner.Ready()
+			// Во время работы сброс недоступен
+			assert.ErrorIs(t, runner.Reset(), ErrRunning)
+			cancel()
+		}()
+
+		require.NoError(t, runner.Run(ctx))
+	}
+
+	run()
+	require.NoError(t, runner.Reset())
+	assert.Equal(t, StateIdle, runner.State())
+	assert.Empty(t, runner.Summary().Apps)
+	run()
+
+	assert.Equal(t, []string{"start:api", "stop:api", "hook", "start:api", "stop:api", "hook"}, recorder.Calls())
+}
+
+func TestAppsRunner_Run_Optional(t *testing.T) {
+	recorder := &callRecorder{}
+
+	exporter := &MockApp{}
+	exporter.On("Start").Return(errors.New("port in use"))
+
+	runner := New(&recordingLogger{recorder: recorder})
+	runner.RegisterNamedApp("exporter", exporter, WithOptional())
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	// Ошибка необязательного приложения не прерывает запуск
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"api"}, recorder.filter("start:"))
+	assert.Equal(t, []string{"api"}, recorder.filter("stop:"))
+	assert.Contains(t, recorder.filter("warn:"), "optional application failed to start, continuing")
+	exporter.AssertNotCalled(t, "Stop")
+}
+
+func TestAppsRunner_Run_ReadyTimeout(t *testing.T) {
+	stopping := make(chan struct{})
+	stopped := false
+
+	runner := New(discardLogger{}, WithReadyTimeout(50*time.Millisecond))
+	runner.RegisterReadyApp("server", func(chan<- struct{}) error {
+		// Приложение не сообщает о готовности
+		<-stopping
+		return nil
+	}, func() error {
+		stopped = true
+		close(stopping)
+		return nil
+	})
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrReadyTimeout)
+	assert.EqualError(t, err, `app "server" start: ready timeout exceeded`)
+	assert.True(t, stopped)
+}
+
+func TestAppsRunner_Run_ReadyTimeoutWarmup(t *testing.T) {
+	appMock := &MockWarmerApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Warmup", mock.Anything).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+	}).Return(context.Canceled)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(discardLogger{}, WithReadyTimeout(50*time.Millisecond))
+	runner.RegisterNamedApp("cache", appMock)
+
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrReadyTimeout)
+	assert.EqualError(t, err, `app "cache" start: warmup: ready timeout exceeded`)
+	appMock.AssertCalled(t, "Stop")
+}
+
+func TestAppsRunner_Run_StopFirstLast(t *testing.T) {
+	recorder := &callRecorder{}
+	app := func(name string) *recordingApp {
+		return &recordingApp{name: name, recorder: recorder}
+	}
+
+	// Приложения WithStopLast останавливаются после всех, включая свои зависимости
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("telemetry", app("telemetry"), WithStopLast())
+	runner.RegisterNamedApp("logger", app("logger"), WithStopLast())
+	runner.RegisterNamedApp("db", app("db"), WithPriority(1))
+	runner.RegisterNamedApp("api", app("api"), DependsOn("db", "telemetry"))
+	runner.RegisterNamedApp("ingress", app("ingress"), WithStopFirst())
+
+	plan, err := runner.Plan()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"ingress", "api", "db", "logger", "telemetry"}, plan.Stop)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.Equal(t, []string{"ingress", "api", "db", "logger", "telemetry"}, recorder.filter("stop:"))
+}
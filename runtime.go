@@ -0,0 +1,195 @@
+package go_runner
+
+import (
+	"context"
+	"fmt"
+	"slices"
+)
+
+// dynamicApp приложение, запущенное StartApp во время Run
+type dynamicApp struct {
+	name     string
+	instance App
+	// starting закрывается после возврата из Start
+	starting   chan struct{}
+	started    bool
+	stopCalled bool
+}
+
+// StartApp запускает приложение во время Run, когда Runner находится в
+// состоянии StateRunning, — например, подключаемый модуль. Start вызывается
+// сразу, без учета зависимостей и приоритетов; после успешного запуска
+// приложение останавливается при завершении Runner раньше зарегистрированных
+// приложений, в порядке, обратном запуску, и может быть остановлено StopApp.
+// Ошибка Start возвращается вызывающему и не останавливает остальные
+// приложения. Имя должно быть непустым и уникальным, иначе возвращается
+// ErrEmptyName или ErrDuplicateName; вне StateRunning возвращается
+// ErrNotRunning. С отмененным ctx приложение не запускается. Как и при
+// регистрации, nil приложение вызывает панику. Безопасен для конкурентного
+// вызова.
+func (r *Runner) StartApp(ctx context.Context, name string, instance App) error {
+	mustNotBeNil(name, instance)
+
+	if name == "" {
+		return ErrEmptyName
+	}
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("app %q start: %w", name, err)
+	}
+
+	d := &dynamicApp{name: name, instance: instance, starting: make(chan struct{})}
+
+	r.mu.Lock()
+	if r.lifecycle != StateRunning {
+		r.mu.Unlock()
+		return fmt.Errorf("app %q start: %w", name, ErrNotRunning)
+	}
+	if r.indexOf(name) >= 0 || slices.ContainsFunc(r.dynamic, func(d *dynamicApp) bool { return d.name == name }) {
+		r.mu.Unlock()
+		return fmt.Errorf("%w: %q", ErrDuplicateName, name)
+	}
+	r.dynamic = append(r.dynamic, d)
+	r.mu.Unlock()
+
+	// Остановка дожидается возврата из Start, начатого до ее начала
+	defer close(d.starting)
+
+	r.logger.Debug(r.messages.StartApplication, r.messages.AppKey, name)
+	if err := r.callDynamic(d, instance.Start); err != nil {
+		r.mu.Lock()
+		r.dynamic = slices.DeleteFunc(r.dynamic, func(other *dynamicApp) bool { return other == d })
+		r.mu.Unlock()
+
+		return fmt.Errorf("app %q start: %w", name, err)
+	}
+
+	r.mu.Lock()
+	d.started = true
+	r.runningCount.Add(1)
+	r.mu.Unlock()
+
+	r.logger.Debug(r.messages.ApplicationStarted, r.messages.AppKey, name)
+	return nil
+}
+
+// StopApp останавливает запущенное приложение с указанным именем во время Run,
+// не затрагивая остальные: вызывает его Stop с контекстом ctx и помечает
+// приложение остановленным, поэтому при остановке Runner оно пропускается.
+// Возвращает ErrEmptyName для пустого имени, ErrAppNotRunning, если Runner не в
+// состоянии StateRunning или приложение не запущено, и ошибку Stop приложения.
+func (r *Runner) StopApp(ctx context.Context, name string) error {
+	if name == "" {
+		return ErrEmptyName
+	}
+	if r.State() != StateRunning {
+		return fmt.Errorf("%w: %q", ErrAppNotRunning, name)
+	}
+
+	i := r.indexOf(name)
+	if i < 0 {
+		d := r.findDynamic(name)
+		if d == nil {
+			return fmt.Errorf("%w: %q", ErrAppNotRunning, name)
+		}
+		return r.stopDynamic(d, true)
+	}
+
+	// Проверка и снятие отметки о запуске выполняются атомарно, чтобы Stop не
+	// был вызван дважды при параллельных вызовах
+	running := false
+	r.updateState(i, func(st *appState) {
+		running = st.running()
+		if running {
+			st.started = false
+		}
+	})
+	if !running {
+		return fmt.Errorf("%w: %q", ErrAppNotRunning, name)
+	}
+
+	if r.apps[i].Stop == nil {
+		r.updateState(i, func(st *appState) { st.stopped = true })
+		return nil
+	}
+
+	return r.stopApp(ctx, i)
+}
+
+// indexOf возвращает индекс приложения с указанным именем или -1.
+func (r *Runner) indexOf(name string) int {
+	for i, a := range r.apps {
+		if a.Start != nil && a.Name == name {
+			return i
+		}
+	}
+
+	return -1
+}
+
+// findDynamic возвращает приложение StartApp с указанным именем или nil.
+func (r *Runner) findDynamic(name string) *dynamicApp {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	i := slices.IndexFunc(r.dynamic, func(d *dynamicApp) bool { return d.name == name })
+	if i < 0 {
+		return nil
+	}
+
+	return r.dynamic[i]
+}
+
+// stopDynamics останавливает приложения StartApp в порядке, обратном запуску,
+// дождавшись возврата из начатых Start, и возвращает последнюю ошибку Stop.
+// Вызывается в начале остановки, когда новые приложения уже не запускаются.
+func (r *Runner) stopDynamics() error {
+	r.mu.Lock()
+	dynamic := slices.Clone(r.dynamic)
+	r.mu.Unlock()
+
+	var err error
+	for i := len(dynamic) - 1; i >= 0; i-- {
+		d := dynamic[i]
+		<-d.starting
+		if stopErr := r.stopDynamic(d, false); stopErr != nil {
+			err = stopErr
+		}
+	}
+
+	return err
+}
+
+// stopDynamic вызывает Stop приложения StartApp не более одного раза. Если
+// приложение не запущено или уже остановлено, возвращает ErrAppNotRunning при
+// strict и nil без него.
+func (r *Runner) stopDynamic(d *dynamicApp, strict bool) error {
+	r.mu.Lock()
+	if !d.started || d.stopCalled {
+		r.mu.Unlock()
+		if strict {
+			return fmt.Errorf("%w: %q", ErrAppNotRunning, d.name)
+		}
+		return nil
+	}
+	d.stopCalled = true
+	r.runningCount.Add(-1)
+	r.mu.Unlock()
+
+	r.logger.Debug(r.messages.StopApplication, r.messages.AppKey, d.name)
+	err := r.callDynamic(d, d.instance.Stop)
+	if err != nil {
+		r.logger.Error(r.messages.StopError, r.messages.AppKey, d.name, r.messages.ErrorKey, err)
+
+		r.mu.Lock()
+		r.failures = append(r.failures, Failure{Phase: PhaseStop, App: d.name, Err: err})
+		r.mu.Unlock()
+	}
+
+	return err
+}
+
+// callDynamic вызывает fn приложения StartApp, преобразуя панику в ошибку
+// ErrPanic так же, как safeCall.
+func (r *Runner) callDynamic(d *dynamicApp, fn func() error) error {
+	return r.recoverCall(d.name, []any{r.messages.AppKey, d.name}, fn)
+}
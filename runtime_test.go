@@ -0,0 +1,93 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_StopApp(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	for _, name := range []string{"db", "cache", "api"} {
+		runner.RegisterNamedApp(name, &recordingApp{name: name, recorder: recorder})
+	}
+	runner.RegisterApp(&recordingApp{name: "anonymous", recorder: recorder})
+
+	// До запуска приложение не считается запущенным
+	require.ErrorIs(t, runner.StopApp(context.Background(), "cache"), ErrAppNotRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	<-runner.Ready()
+	require.NoError(t, runner.StopApp(context.Background(), "cache"))
+
+	// Остальные приложения продолжают работать
+	assert.Equal(t, StateRunning, runner.State())
+	assert.Equal(t, 3, runner.RunningCount())
+	assert.Equal(t, []string{"cache"}, recorder.filter("stop:"))
+
+	// Повторная остановка и неизвестное имя возвращают ошибку
+	require.ErrorIs(t, runner.StopApp(context.Background(), "cache"), ErrAppNotRunning)
+	require.ErrorIs(t, runner.StopApp(context.Background(), "queue"), ErrAppNotRunning)
+
+	// Пустое имя не совпадает с безымянными приложениями
+	require.ErrorIs(t, runner.StopApp(context.Background(), ""), ErrEmptyName)
+	assert.Equal(t, []string{"cache"}, recorder.filter("stop:"))
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"cache", "anonymous", "api", "db"}, recorder.filter("stop:"))
+}
+
+func TestAppsRunner_StartApp(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("db", &recordingApp{name: "db", recorder: recorder})
+
+	// До запуска Runner приложение не запускается
+	require.ErrorIs(t, runner.StartApp(context.Background(), "plugin", &recordingApp{name: "plugin", recorder: recorder}), ErrNotRunning)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() { done <- runner.Run(ctx) }()
+
+	<-runner.Ready()
+	require.NoError(t, runner.StartApp(context.Background(), "plugin", &recordingApp{name: "plugin", recorder: recorder}))
+	require.NoError(t, runner.StartApp(context.Background(), "exporter", &recordingApp{name: "exporter", recorder: recorder}))
+	assert.Equal(t, 3, runner.RunningCount())
+
+	// Имя должно быть непустым и уникальным среди всех приложений
+	require.ErrorIs(t, runner.StartApp(context.Background(), "db", &MockApp{}), ErrDuplicateName)
+	require.ErrorIs(t, runner.StartApp(context.Background(), "", &MockApp{}), ErrEmptyName)
+
+	// Ошибка Start возвращается вызывающему и не останавливает Runner
+	failing := &MockApp{}
+	failing.On("Start").Return(errors.New("boom"))
+	require.EqualError(t, runner.StartApp(context.Background(), "broken", failing), `app "broken" start: boom`)
+	assert.Equal(t, StateRunning, runner.State())
+
+	// nil приложение вызывает панику, как при регистрации, и не добавляется
+	assert.PanicsWithValue(t, `go_runner: nil app "empty"`, func() {
+		_ = runner.StartApp(context.Background(), "empty", (*MockApp)(nil))
+	})
+	empty := &MockApp{}
+	empty.On("Start").Return(nil).Once()
+	empty.On("Stop").Return(nil).Once()
+	require.NoError(t, runner.StartApp(context.Background(), "empty", empty))
+
+	cancel()
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"db", "plugin", "exporter"}, recorder.filter("start:"))
+	assert.Equal(t, []string{"exporter", "plugin", "db"}, recorder.filter("stop:"))
+	assert.Zero(t, runner.RunningCount())
+	failing.AssertNotCalled(t, "Stop")
+	empty.AssertExpectations(t)
+}
@@ -0,0 +1,295 @@
+package go_runner
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"slices"
+	"syscall"
+	"time"
+)
+
+// signalBuffer размер буфера канала сигналов. signal.Notify не блокируется при
+// заполненном канале, поэтому сигналы сверх буфера во время обработки
+// предыдущего отбрасываются, а не накапливаются.
+const signalBuffer = 8
+
+// handleSignals обрабатывает сигналы до завершения остановки. SIGTERM, SIGINT и
+// канал WithTriggerChannel запускают остановку, SIGHUP перезапускает приложения
+// с WithRestartOnReload или весь процесс при WithReExecOnReload. SIGTERM или
+// SIGINT, полученный во время остановки, закрывает force, прерывая остановку
+// приложений. При WithoutSignalHandling сигналы не отслеживаются.
+func (r *Runner) handleSignals(ctx context.Context, cancel context.CancelCauseFunc, waves [][]int, startupDone <-chan struct{}, force chan<- struct{}, stopped <-chan struct{}) error {
+	// Без подписки канал остается nil и никогда не получает значений
+	var ch chan os.Signal
+	if !r.withoutSignals {
+		sig := []os.Signal{syscall.SIGTERM, syscall.SIGINT}
+		if r.restartsOnReload() || r.reExecOnReload {
+			sig = append(sig, syscall.SIGHUP)
+		}
+		for _, h := range r.signalHandlers {
+			if !slices.Contains(sig, h.signal) {
+				sig = append(sig, h.signal)
+			}
+		}
+
+		// Канал не закрывается: после stopNotify в него больше не отправляются сигналы
+		ch = make(chan os.Signal, signalBuffer)
+		r.subscribe(ch, sig)
+		defer r.unsubscribe()
+	}
+
+	err := r.awaitShutdown(ctx, cancel, ch, waves, startupDone)
+
+	// Повторный запрос остановки прерывает ее
+	for {
+		select {
+		case s := <-ch:
+			if s != syscall.SIGTERM && s != syscall.SIGINT {
+				continue
+			}
+
+			r.logger.Warn(r.messages.ForcingShutdown, r.messages.SignalKey, s)
+			close(force)
+			return err
+		case <-stopped:
+			return err
+		}
+	}
+}
+
+// subscribe подписывает ch на сигналы sig, если обработка сигналов не
+// приостановлена PauseSignals.
+func (r *Runner) subscribe(ch chan os.Signal, sig []os.Signal) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.signalCh = ch
+	r.signalSet = sig
+	if !r.signalsPaused {
+		r.notify(ch, sig...)
+	}
+}
+
+// unsubscribe отменяет подписку на сигналы по завершении Run.
+func (r *Runner) unsubscribe() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.stopNotify(r.signalCh)
+	r.signalCh = nil
+}
+
+// PauseSignals приостанавливает обработку сигналов, не прерывая работу
+// приложений, например на время критической секции. Сигнал, полученный до
+// ResumeSignals, не запускает остановку и не обрабатывается позже. Если других
+// подписчиков на сигнал нет, Go выполняет действие по умолчанию — завершает
+// процесс без остановки приложений. Вызов до Run приостанавливает обработку с
+// начала запуска.
+func (r *Runner) PauseSignals() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.signalsPaused {
+		return
+	}
+	r.signalsPaused = true
+
+	if r.signalCh != nil {
+		r.stopNotify(r.signalCh)
+	}
+}
+
+// ResumeSignals возобновляет обработку сигналов, приостановленную PauseSignals.
+func (r *Runner) ResumeSignals() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.signalsPaused {
+		return
+	}
+	r.signalsPaused = false
+
+	if r.signalCh != nil {
+		r.notify(r.signalCh, r.signalSet...)
+	}
+}
+
+// awaitShutdown ожидает сигнала, срабатывания WithTriggerChannel или отмены
+// контекста и отменяет контекст Run с соответствующей причиной.
+func (r *Runner) awaitShutdown(ctx context.Context, cancel context.CancelCauseFunc, ch <-chan os.Signal, waves [][]int, startupDone <-chan struct{}) error {
+	for {
+		select {
+		case s := <-ch:
+			// Обработчик SIGHUP не отменяет перезапуск и вызывается перед ним
+			handled := r.handleCustomSignal(s)
+
+			if s == syscall.SIGHUP && r.reExecOnReload {
+				if !r.requestReExec(startupDone) {
+					continue
+				}
+
+				r.mu.Lock()
+				r.signal = s
+				r.mu.Unlock()
+
+				err := &SignalError{Signal: s}
+				cancel(err)
+				return err
+			}
+
+			if s == syscall.SIGHUP && r.restartsOnReload() {
+				if err := r.reload(ctx, waves, startupDone); err != nil {
+					cancel(startFailed(err))
+					return err
+				}
+				continue
+			}
+
+			if handled {
+				continue
+			}
+
+			if !r.awaitMinUptime(ctx, cancel, ch, s) {
+				return nil
+			}
+
+			r.mu.Lock()
+			r.signal = s
+			r.mu.Unlock()
+
+			err := &SignalError{Signal: s}
+			cancel(err)
+			return err
+		case <-r.trigger:
+			r.logger.Debug(r.messages.ShuttingDownByTrigger)
+			cancel(ErrTriggered)
+			return nil
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// awaitMinUptime откладывает остановку по сигналу s до истечения WithMinUptime с
+// начала Run. Повторные сигналы остановки в это время отбрасываются, чтобы не
+// прервать последующую остановку, а обработчики RegisterSignalHandler вызываются.
+// Срабатывание WithTriggerChannel не откладывается и отменяет контекст Run с
+// причиной ErrTriggered. Возвращает false, если контекст Run отменен раньше.
+func (r *Runner) awaitMinUptime(ctx context.Context, cancel context.CancelCauseFunc, ch <-chan os.Signal, s os.Signal) bool {
+	r.mu.Lock()
+	remaining := r.minUptime - time.Since(r.startedAt)
+	r.mu.Unlock()
+
+	if remaining <= 0 {
+		return true
+	}
+
+	r.logger.Info(r.messages.SignalDeferred, r.messages.SignalKey, s, r.messages.DelayKey, remaining)
+
+	timer := time.NewTimer(remaining)
+	defer timer.Stop()
+
+	for {
+		select {
+		case next := <-ch:
+			r.handleCustomSignal(next)
+		case <-r.trigger:
+			r.logger.Debug(r.messages.ShuttingDownByTrigger)
+			cancel(ErrTriggered)
+			return false
+		case <-timer.C:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	}
+}
+
+// RegisterSignalHandler регистрирует обработчик сигнала, не приводящего к
+// остановке, например SIGUSR1 для вывода состояния или ротации логов. Обработчик
+// вызывается в горутине обработки сигналов без отмены контекста Run, его ошибка
+// логируется. Обработчик SIGHUP вызывается перед перезапуском по
+// WithRestartOnReload или WithReExecOnReload. SIGTERM и SIGINT всегда запускают остановку, регистрация
+// обработчика для них приводит к панике. При WithoutSignalHandling обработчики
+// не вызываются.
+func (r *Runner) RegisterSignalHandler(sig os.Signal, handler callback) {
+	if handler == nil {
+		return
+	}
+	if sig == syscall.SIGTERM || sig == syscall.SIGINT {
+		panic(fmt.Sprintf("go_runner: RegisterSignalHandler for shutdown signal %v", sig))
+	}
+
+	r.signalHandlers = append(r.signalHandlers, signalHandler{signal: sig, handler: handler})
+}
+
+// handleCustomSignal вызывает обработчики, зарегистрированные для s через
+// RegisterSignalHandler, и сообщает, были ли они.
+func (r *Runner) handleCustomSignal(s os.Signal) bool {
+	handled := false
+	for _, h := range r.signalHandlers {
+		if h.signal != s {
+			continue
+		}
+		handled = true
+
+		r.logger.Debug(r.messages.HandlingSignal, r.messages.SignalKey, s)
+		if err := h.handler(); err != nil {
+			r.logger.Error(r.messages.SignalHandlerError, r.messages.SignalKey, s, r.messages.ErrorKey, err)
+		}
+	}
+
+	return handled
+}
+
+// restartsOnReload сообщает, есть ли приложения, перезапускаемые по SIGHUP.
+func (r *Runner) restartsOnReload() bool {
+	return slices.ContainsFunc(r.apps, func(a appStruct) bool {
+		return a.RestartOnReload
+	})
+}
+
+// reload останавливает запущенные приложения с WithRestartOnReload в порядке
+// остановки и запускает их заново в порядке запуска, остальные приложения
+// продолжают работу. Приложения, остановленные через StopApp или не
+// запустившиеся, не перезапускаются. Ошибка повторного запуска приводит к
+// остановке всех приложений.
+func (r *Runner) reload(ctx context.Context, waves [][]int, startupDone <-chan struct{}) error {
+	select {
+	case <-startupDone:
+	default:
+		r.logger.Warn(r.messages.ReloadIgnored)
+		return nil
+	}
+
+	r.logger.Info(r.messages.Reloading)
+
+	restart := make(map[int]bool)
+	for _, i := range stopOrder(waves) {
+		if !r.apps[i].RestartOnReload || !r.state(i).started {
+			continue
+		}
+
+		restart[i] = true
+		r.updateState(i, func(st *appState) { st.started = false })
+		_ = r.stopApp(context.WithoutCancel(ctx), i)
+	}
+
+	for _, i := range slices.Concat(waves...) {
+		if !restart[i] {
+			continue
+		}
+
+		// Остановка могла начаться во время перезапуска
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		if err := r.startApp(ctx, i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
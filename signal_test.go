@@ -0,0 +1,681 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"os"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeSignals эмулирует доставку сигналов без отправки их процессу
+type fakeSignals struct {
+	mu   sync.Mutex
+	subs map[chan<- os.Signal][]os.Signal
+}
+
+func newFakeSignals(r *Runner) *fakeSignals {
+	f := &fakeSignals{subs: make(map[chan<- os.Signal][]os.Signal)}
+	r.notify = func(c chan<- os.Signal, sig ...os.Signal) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		f.subs[c] = append(f.subs[c], sig...)
+	}
+	r.stopNotify = func(c chan<- os.Signal) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		delete(f.subs, c)
+	}
+	return f
+}
+
+// send дожидается подписки на сигнал и доставляет его так же, как signal.Notify
+func (f *fakeSignals) send(t *testing.T, sig os.Signal) {
+	t.Helper()
+
+	assert.Eventually(t, func() bool { return f.deliver(sig) }, time.Second, time.Millisecond)
+}
+
+// deliver доставляет сигнал подписчикам без блокировки и сообщает, были ли они
+func (f *fakeSignals) deliver(sig os.Signal) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	delivered := false
+	for c, sigs := range f.subs {
+		if !slices.Contains(sigs, sig) {
+			continue
+		}
+		select {
+		case c <- sig:
+		default:
+		}
+		delivered = true
+	}
+	return delivered
+}
+
+// subscribed сообщает, остались ли подписки на сигналы
+func (f *fakeSignals) subscribed() bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	return len(f.subs) > 0
+}
+
+func TestAppsRunner_Run_SignalPolicy(t *testing.T) {
+	loggerMock := &MockLogger{}
+	appMock := &MockApp{}
+
+	// Stop зависает до завершения теста
+	block := make(chan struct{})
+	defer close(block)
+
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Run(func(mock.Arguments) { <-block }).Return(nil)
+
+	loggerMock.On("Debug", "start application", "app", "").Once()
+	ready := make(chan struct{})
+	loggerMock.On("Debug", "application started", "app", "").Run(func(mock.Arguments) { close(ready) }).Once()
+	loggerMock.On("Debug", "stop application", "app", "").Once()
+	loggerMock.On("Error", "shutdown timeout exceeded", "timeout", 50*time.Millisecond).Once()
+	loggerMock.On("Warn", "application stop straggler", "app", "").Once()
+	loggerMock.On("Debug", "shutting down by signal").Once()
+	loggerMock.On("Error", "terminating with error", "error", ErrShutdownTimeout).Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
+
+	runner := New(loggerMock,
+		WithShutdownTimeout(time.Minute),
+		WithSignalPolicy(syscall.SIGINT, Policy{ShutdownTimeout: 50 * time.Millisecond}),
+	)
+	runner.RegisterApp(appMock)
+	signals := newFakeSignals(runner)
+
+	go func() {
+		<-ready
+		signals.send(t, syscall.SIGINT)
+	}()
+
+	startedAt := time.Now()
+	err := runner.Run(context.Background())
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+
+	appMock.AssertExpectations(t)
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_RestartOnReload(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("steady", &recordingApp{name: "steady", recorder: recorder})
+	runner.RegisterNamedApp("restartable", &recordingApp{name: "restartable", recorder: recorder}, WithRestartOnReload())
+	signals := newFakeSignals(runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		signals.send(t, syscall.SIGHUP)
+
+		assert.Eventually(t, func() bool { return len(recorder.filter("start:")) == 3 }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	calls := recorder.Calls()
+	var restartable, steady []string
+	for _, call := range calls {
+		switch {
+		case strings.HasSuffix(call, ":restartable"):
+			restartable = append(restartable, call)
+		case strings.HasSuffix(call, ":steady"):
+			steady = append(steady, call)
+		}
+	}
+
+	assert.Equal(t, []string{"start:restartable", "stop:restartable", "start:restartable", "stop:restartable"}, restartable)
+	assert.Equal(t, []string{"start:steady", "stop:steady"}, steady)
+}
+
+func TestAppsRunner_Run_RestartOnReloadSkipsStoppedApps(t *testing.T) {
+	recorder := &callRecorder{}
+	var optionalStarts atomic.Int32
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("restartable", &recordingApp{name: "restartable", recorder: recorder}, WithRestartOnReload())
+	runner.RegisterNamedApp("stopped", &recordingApp{name: "stopped", recorder: recorder}, WithRestartOnReload())
+	runner.RegisterStartOnly("optional", func() error {
+		optionalStarts.Add(1)
+		return errors.New("unavailable")
+	}, WithRestartOnReload(), WithOptional())
+	signals := newFakeSignals(runner)
+
+	count := func(prefix, name string) int {
+		n := 0
+		for _, call := range recorder.filter(prefix) {
+			if call == name {
+				n++
+			}
+		}
+		return n
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		assert.NoError(t, runner.StopApp(ctx, "stopped"))
+		signals.send(t, syscall.SIGHUP)
+
+		assert.Eventually(t, func() bool { return count("start:", "restartable") == 2 }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	assert.Equal(t, 1, count("start:", "stopped"))
+	assert.Equal(t, 1, count("stop:", "stopped"))
+	assert.Equal(t, int32(1), optionalStarts.Load())
+}
+
+func TestAppsRunner_Run_RestartOnReloadWithSignalHandler(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	runner.RegisterNamedApp("restartable", &recordingApp{name: "restartable", recorder: recorder}, WithRestartOnReload())
+	runner.RegisterSignalHandler(syscall.SIGHUP, func() error {
+		recorder.record("handler")
+		return nil
+	})
+	signals := newFakeSignals(runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		signals.send(t, syscall.SIGHUP)
+
+		assert.Eventually(t, func() bool { return len(recorder.filter("start:")) == 2 }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	// Обработчик вызывается перед перезапуском и не отменяет его
+	assert.Equal(t, []string{
+		"start:restartable", "handler", "stop:restartable", "start:restartable", "stop:restartable",
+	}, recorder.Calls())
+}
+
+func TestAppsRunner_Run_ReExecOnReload(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{}, WithReExecOnReload())
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	signals := newFakeSignals(runner)
+
+	var argv []string
+	runner.exec = func(_ string, args []string, _ []string) error {
+		recorder.record("exec")
+		argv = args
+		return nil
+	}
+
+	go func() {
+		<-runner.Ready()
+		signals.send(t, syscall.SIGHUP)
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+
+	// Процесс заменяется с исходными аргументами после остановки приложений
+	assert.Equal(t, []string{"start:api", "stop:api", "exec"}, recorder.Calls())
+	assert.Equal(t, os.Args, argv)
+}
+
+func TestAppsRunner_Run_ReExecOnReload_StopError(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(errors.New("stop failed"))
+
+	runner := New(discardLogger{}, WithReExecOnReload())
+	runner.RegisterNamedApp("api", appMock)
+	signals := newFakeSignals(runner)
+	runner.exec = func(string, []string, []string) error {
+		t.Error("exec after failed shutdown")
+		return nil
+	}
+
+	go func() {
+		<-runner.Ready()
+		signals.send(t, syscall.SIGHUP)
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_FailOnStopError(t *testing.T) {
+	stopErr := errors.New("flush failed")
+
+	run := func(opts ...Option) error {
+		appMock := &MockApp{}
+		appMock.On("Start").Return(nil)
+		appMock.On("Stop").Return(stopErr)
+
+		runner := New(discardLogger{}, opts...)
+		runner.RegisterNamedApp("api", appMock)
+		signals := newFakeSignals(runner)
+
+		go func() {
+			<-runner.Ready()
+			signals.send(t, syscall.SIGTERM)
+		}()
+
+		return runner.Run(context.Background())
+	}
+
+	// По умолчанию ошибка Stop при остановке по сигналу только логируется
+	require.NoError(t, run())
+	require.NoError(t, run(WithFailOnStopError(false)))
+
+	err := run(WithFailOnStopError(true))
+	require.ErrorIs(t, err, stopErr)
+}
+
+func TestAppsRunner_Run_TriggerChannel(t *testing.T) {
+	loggerMock := &MockLogger{}
+	appMock := &MockApp{}
+
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(nil).Once()
+
+	loggerMock.On("Debug", "start application", "app", "").Once()
+	loggerMock.On("Debug", "application started", "app", "").Once()
+	loggerMock.On("Debug", "shutting down by trigger").Once()
+	loggerMock.On("Debug", "stop application", "app", "").Once()
+	onStopped(loggerMock, 1, 0, 0).Once()
+
+	trigger := make(chan struct{})
+	runner := New(loggerMock, WithTriggerChannel(trigger))
+	runner.RegisterApp(appMock)
+	newFakeSignals(runner)
+
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		close(trigger)
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+
+	appMock.AssertExpectations(t)
+	loggerMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_CancelCauseSignal(t *testing.T) {
+	appMock := &MockContextApp{}
+
+	startCtx := make(chan context.Context, 1)
+	appMock.On("Start", mock.Anything).Run(func(args mock.Arguments) {
+		startCtx <- args.Get(0).(context.Context)
+	}).Return(nil)
+	appMock.On("Stop", mock.Anything).Return(nil)
+
+	runner := New(discardLogger{})
+	runner.RegisterContextApp("server", appMock)
+	signals := newFakeSignals(runner)
+
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		signals.send(t, syscall.SIGTERM)
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+
+	ctx := <-startCtx
+	assert.ErrorIs(t, context.Cause(ctx), ErrInterruptedBySignal)
+
+	var sigErr *SignalError
+	require.ErrorAs(t, context.Cause(ctx), &sigErr)
+	assert.Equal(t, syscall.SIGTERM, sigErr.Signal)
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_WithoutSignalHandling(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(discardLogger{}, WithoutSignalHandling())
+	runner.RegisterApp(appMock, WithRestartOnReload())
+
+	var installed bool
+	runner.notify = func(chan<- os.Signal, ...os.Signal) { installed = true }
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	assert.False(t, installed, "signal handler installed")
+	appMock.AssertExpectations(t)
+}
+
+func TestAppsRunner_Run_SignalBurst(t *testing.T) {
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	// Прерванная повторным сигналом остановка может не дождаться Stop, поэтому
+	// Stop необязателен, но вызывается не более одного раза
+	var stops atomic.Int32
+	appMock.On("Stop").Run(func(mock.Arguments) { stops.Add(1) }).Return(nil).Maybe()
+
+	runner := New(discardLogger{})
+	runner.RegisterApp(appMock, WithRestartOnReload())
+	signals := newFakeSignals(runner)
+
+	// Пачка сигналов из нескольких горутин, в том числе после завершения обработки
+	var wg sync.WaitGroup
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		wg.Add(10)
+		for range 10 {
+			go func() {
+				defer wg.Done()
+				for _, sig := range []os.Signal{syscall.SIGTERM, syscall.SIGINT, syscall.SIGTERM} {
+					signals.deliver(sig)
+				}
+			}()
+		}
+	}()
+
+	// Повторные сигналы могут прервать остановку
+	if err := runner.Run(context.Background()); err != nil {
+		require.ErrorIs(t, err, ErrForcedShutdown)
+	}
+	wg.Wait()
+
+	assert.False(t, signals.subscribed(), "signal subscription leaked")
+	appMock.AssertExpectations(t)
+	assert.LessOrEqual(t, stops.Load(), int32(1))
+}
+
+func TestAppsRunner_Run_ForcedShutdown(t *testing.T) {
+	for _, tt := range []struct {
+		name    string
+		timeout time.Duration
+		force   bool
+		want    error
+	}{
+		{name: "second signal", timeout: time.Minute, force: true, want: ErrForcedShutdown},
+		{name: "timeout", timeout: 50 * time.Millisecond, force: false, want: ErrShutdownTimeout},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			appMock := &MockContextApp{}
+
+			stopping := make(chan struct{})
+			cause := make(chan error, 1)
+			appMock.On("Start", mock.Anything).Return(nil)
+			appMock.On("Stop", mock.Anything).Run(func(args mock.Arguments) {
+				ctx := args.Get(0).(context.Context)
+				close(stopping)
+				<-ctx.Done()
+				cause <- context.Cause(ctx)
+			}).Return(context.Canceled)
+
+			runner := New(discardLogger{}, WithShutdownTimeout(tt.timeout))
+			runner.RegisterContextApp("slow", appMock)
+			signals := newFakeSignals(runner)
+
+			go func() {
+				assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+				signals.send(t, syscall.SIGTERM)
+
+				<-stopping
+				if tt.force {
+					signals.send(t, syscall.SIGINT)
+				}
+			}()
+
+			startedAt := time.Now()
+			err := runner.Run(context.Background())
+			require.ErrorIs(t, err, tt.want)
+			assert.Less(t, time.Since(startedAt), 5*time.Second)
+
+			// Контекст Stop отменяется с той же причиной
+			select {
+			case got := <-cause:
+				assert.ErrorIs(t, got, tt.want)
+			case <-time.After(time.Second):
+				t.Fatal("Stop context not cancelled")
+			}
+		})
+	}
+}
+
+func TestAppsRunner_Run_SignalMode(t *testing.T) {
+	for _, tt := range []struct {
+		name  string
+		mode  SignalMode
+		check func(t *testing.T, err error)
+	}{
+		{name: "nil", mode: SignalAsNil, check: func(t *testing.T, err error) {
+			require.NoError(t, err)
+		}},
+		{name: "error", mode: SignalAsError, check: func(t *testing.T, err error) {
+			require.ErrorIs(t, err, ErrInterruptedBySignal)
+
+			var sigErr *SignalError
+			require.ErrorAs(t, err, &sigErr)
+			assert.Equal(t, syscall.SIGTERM, sigErr.Signal)
+			assert.EqualError(t, err, "process interrupted by signal: terminated")
+		}},
+		{name: "exit code", mode: SignalAsExitCode, check: func(t *testing.T, err error) {
+			require.ErrorIs(t, err, ErrInterruptedBySignal)
+
+			var exitErr *ExitCodeError
+			require.ErrorAs(t, err, &exitErr)
+			assert.Equal(t, syscall.SIGTERM, exitErr.Signal)
+			assert.Equal(t, 143, exitErr.Code)
+
+			var sigErr *SignalError
+			require.ErrorAs(t, err, &sigErr)
+			assert.Equal(t, syscall.SIGTERM, sigErr.Signal)
+		}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			appMock := &MockApp{}
+			appMock.On("Start").Return(nil)
+			appMock.On("Stop").Return(nil)
+
+			runner := New(discardLogger{}, WithSignalMode(tt.mode))
+			runner.RegisterApp(appMock)
+			signals := newFakeSignals(runner)
+
+			go func() {
+				assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+				signals.send(t, syscall.SIGTERM)
+			}()
+
+			tt.check(t, runner.Run(context.Background()))
+			appMock.AssertExpectations(t)
+		})
+	}
+}
+
+func TestAppsRunner_PauseSignals(t *testing.T) {
+	runner := New(discardLogger{})
+	runner.RegisterStartOnly("api", func() error { return nil })
+	signals := newFakeSignals(runner)
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- runner.Run(context.Background()) }()
+
+	<-runner.Ready()
+	require.Eventually(t, signals.subscribed, time.Second, time.Millisecond)
+
+	// Пока обработка приостановлена, сигнал не доставляется и остановка не начинается
+	runner.PauseSignals()
+	assert.False(t, signals.subscribed())
+	assert.False(t, signals.deliver(syscall.SIGTERM))
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, StateRunning, runner.State())
+
+	runner.ResumeSignals()
+	signals.send(t, syscall.SIGTERM)
+
+	select {
+	case err := <-errCh:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("Run did not stop after signals resumed")
+	}
+	assert.False(t, signals.subscribed())
+}
+
+func TestAppsRunner_Run_WarnNeverStarted(t *testing.T) {
+	recorder := &callRecorder{}
+	block := make(chan struct{})
+	defer close(block)
+
+	runner := New(&fieldsLogger{recordingLogger{recorder: recorder}})
+	runner.RegisterStartOnly("api", func() error { return nil })
+	// Start блокируется, хотя приложение зарегистрировано без RegisterReadyApp
+	runner.RegisterStartOnly("server", func() error {
+		<-block
+		return nil
+	})
+	signals := newFakeSignals(runner)
+
+	go func() {
+		assert.Eventually(t, func() bool {
+			return slices.Contains(recorder.Calls(), "debug:start application")
+		}, time.Second, time.Millisecond)
+		signals.send(t, syscall.SIGTERM)
+	}()
+
+	require.NoError(t, runner.Run(context.Background()))
+	assert.Equal(t, []string{"application never reported started app server"}, recorder.filter("warn:"))
+}
+
+func TestAppsRunner_RunAndExit(t *testing.T) {
+	for _, tt := range []struct {
+		name   string
+		start  error
+		signal bool
+		code   int
+	}{
+		{name: "clean", code: 0},
+		{name: "signal", signal: true, code: 143},
+		{name: "error", start: errors.New("boom"), code: 1},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			appMock := &MockApp{}
+			appMock.On("Start").Return(tt.start)
+			appMock.On("Stop").Return(nil).Maybe()
+
+			runner := New(discardLogger{}, WithSignalMode(SignalAsError))
+			runner.RegisterApp(appMock)
+			signals := newFakeSignals(runner)
+
+			code := -1
+			runner.exit = func(c int) { code = c }
+
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			if tt.start == nil {
+				go func() {
+					<-runner.Ready()
+					if tt.signal {
+						signals.send(t, syscall.SIGTERM)
+						return
+					}
+					cancel()
+				}()
+			}
+
+			runner.RunAndExit(ctx)
+			assert.Equal(t, tt.code, code)
+		})
+	}
+}
+
+func TestAppsRunner_Run_MinUptime(t *testing.T) {
+	const minUptime = 200 * time.Millisecond
+
+	var stoppedAt time.Time
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Run(func(mock.Arguments) { stoppedAt = time.Now() }).Return(nil)
+
+	runner := New(discardLogger{}, WithMinUptime(minUptime))
+	runner.RegisterApp(appMock)
+	signals := newFakeSignals(runner)
+
+	// Сигналы сразу после запуска откладываются, повторный не прерывает остановку
+	// с ErrForcedShutdown
+	go func() {
+		signals.send(t, syscall.SIGTERM)
+		signals.send(t, syscall.SIGINT)
+	}()
+
+	start := time.Now()
+	require.NoError(t, runner.Run(context.Background()))
+	assert.GreaterOrEqual(t, stoppedAt.Sub(start), minUptime)
+}
+
+func TestAppsRunner_WithTerminationHandler(t *testing.T) {
+	startErr := errors.New("boom")
+
+	var handled []error
+	runner := New(discardLogger{}, WithTerminationHandler(func(err error) {
+		handled = append(handled, err)
+	}))
+	runner.RegisterStartOnly("api", func() error { return startErr })
+	runner.exit = func(code int) {
+		t.Errorf("unexpected exit with code %d", code)
+	}
+
+	// Обработчик получает итоговую ошибку, а процесс не завершается
+	runner.RunAndExit(context.Background())
+	require.Len(t, handled, 1)
+	require.ErrorIs(t, handled[0], startErr)
+	assert.EqualError(t, handled[0], `app "api" start: boom`)
+}
+
+func TestAppsRunner_Run_MinUptimeTrigger(t *testing.T) {
+	trigger := make(chan struct{})
+
+	appMock := &MockApp{}
+	appMock.On("Start").Return(nil)
+	appMock.On("Stop").Return(nil)
+
+	runner := New(discardLogger{}, WithMinUptime(500*time.Millisecond), WithTriggerChannel(trigger))
+	runner.RegisterApp(appMock)
+	signals := newFakeSignals(runner)
+
+	// Отложенный сигнал не задерживает остановку по WithTriggerChannel
+	go func() {
+		signals.send(t, syscall.SIGTERM)
+		time.Sleep(20 * time.Millisecond)
+		close(trigger)
+	}()
+
+	start := time.Now()
+	result, err := runner.RunResult(context.Background())
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 300*time.Millisecond)
+	assert.Equal(t, TriggerInternal, result.Trigger)
+	assert.Nil(t, result.Signal)
+	appMock.AssertExpectations(t)
+}
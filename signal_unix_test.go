@@ -0,0 +1,47 @@
+//go:build unix
+
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_RegisterSignalHandler(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(&recordingLogger{recorder: recorder})
+	runner.RegisterNamedApp("api", &recordingApp{name: "api", recorder: recorder})
+	runner.RegisterSignalHandler(syscall.SIGUSR1, func() error {
+		recorder.record("dump")
+		return errors.New("dump failed")
+	})
+	signals := newFakeSignals(runner)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		<-runner.Ready()
+		signals.send(t, syscall.SIGUSR1)
+
+		// Обработчик выполняется, а приложения продолжают работу
+		assert.Eventually(t, func() bool { return len(recorder.filter("dump")) == 1 }, time.Second, time.Millisecond)
+		assert.Equal(t, StateRunning, runner.State())
+		assert.Empty(t, recorder.filter("stop:"))
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+	calls := recorder.Calls()
+	assert.Less(t, slices.Index(calls, "dump"), slices.Index(calls, "info:shutdown initiated"))
+
+	assert.PanicsWithValue(t, "go_runner: RegisterSignalHandler for shutdown signal terminated", func() {
+		runner.RegisterSignalHandler(syscall.SIGTERM, func() error { return nil })
+	})
+}
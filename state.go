@@ -0,0 +1,107 @@
+package go_runner
+
+import "time"
+
+// Состояния жизненного цикла Runner
+const (
+	StateIdle     State = "idle"
+	StateStarting State = "starting"
+	StateRunning  State = "running"
+	StateStopping State = "stopping"
+	StateStopped  State = "stopped"
+)
+
+// State состояние жизненного цикла Runner
+type State string
+
+// State возвращает текущее состояние жизненного цикла.
+func (r *Runner) State() State {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.lifecycle
+}
+
+// Ready возвращает канал, который закрывается, когда запущены все приложения.
+// Приложения, зарегистрированные через RegisterReadyApp, считаются запущенными
+// по собственному сигналу готовности.
+func (r *Runner) Ready() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.ready
+}
+
+// RunningCount возвращает число приложений, которые сообщили о запуске и еще не
+// остановлены. Безопасен для вызова из любой горутины во время Run.
+func (r *Runner) RunningCount() int {
+	return int(r.runningCount.Load())
+}
+
+// running сообщает, что приложение запущено и еще не остановлено.
+func (st appState) running() bool {
+	return st.started && !st.stopped
+}
+
+// transition переводит Runner в состояние to, если текущее состояние — from.
+func (r *Runner) transition(from, to State) bool {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.lifecycle != from {
+		return false
+	}
+
+	r.lifecycle = to
+	if to == StateRunning {
+		closeOnce(r.ready)
+	}
+
+	return true
+}
+
+// Stopping возвращает канал, который закрывается в начале остановки — до паузы
+// WithDrainDelay и вызова Stop. Контекст, переданный приложениям, остается
+// активным до окончания паузы, поэтому по Stopping приложения могут перестать
+// принимать новую работу, например переключить readiness probe, и завершить
+// текущую. Если Run завершился без остановки приложений, канал закрывается при
+// возврате из Run.
+func (r *Runner) Stopping() <-chan struct{} {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return r.stopping
+}
+
+// Reset возвращает завершенный Runner в состояние StateIdle, сохраняя
+// зарегистрированные приложения и настройки, чтобы Run можно было вызвать снова.
+// Результаты прошлого запуска сбрасываются, а каналы Ready, Stopping и Done
+// создаются заново. Во время работы Run возвращает ErrRunning.
+func (r *Runner) Reset() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	switch r.lifecycle {
+	case StateStarting, StateRunning, StateStopping:
+		return ErrRunning
+	}
+
+	r.lifecycle = StateIdle
+	r.done = make(chan struct{})
+	r.ready = make(chan struct{})
+	r.stopping = make(chan struct{})
+	r.cancel = nil
+	r.signal = nil
+	r.states = nil
+	r.startedAt = time.Time{}
+	r.duration = 0
+	r.err = nil
+	r.cause = nil
+	r.failures = nil
+	r.downDomains = nil
+	r.deferred = nil
+	r.stragglers = nil
+	r.dynamic = nil
+
+	return nil
+}
@@ -0,0 +1,66 @@
+package go_runner
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+)
+
+// StdLogger Logger на основе стандартного log.Logger для тех, кто не использует
+// slog или zap. Каждое сообщение выводится одной строкой: уровень, текст и пары
+// key=value, например `INFO application started app=api`. Значения с пробелами,
+// кавычками и знаком = заключаются в кавычки.
+type StdLogger struct {
+	logger *log.Logger
+}
+
+// NewStdLogger возвращает StdLogger, пишущий в logger. При logger == nil
+// используется log.Default(), который пишет в os.Stderr.
+func NewStdLogger(logger *log.Logger) Logger {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &StdLogger{logger: logger}
+}
+
+func (l *StdLogger) Debug(msg string, args ...any) { l.print("DEBUG", msg, args) }
+func (l *StdLogger) Error(msg string, args ...any) { l.print("ERROR", msg, args) }
+func (l *StdLogger) Info(msg string, args ...any)  { l.print("INFO", msg, args) }
+func (l *StdLogger) Warn(msg string, args ...any)  { l.print("WARN", msg, args) }
+
+// print выводит строку сообщения. Значение без ключа выводится с ключом !BADKEY,
+// как в slog.
+func (l *StdLogger) print(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+
+	for len(args) > 0 {
+		key, value := "!BADKEY", args[0]
+		if len(args) > 1 {
+			key, value = fmt.Sprint(args[0]), args[1]
+			args = args[2:]
+		} else {
+			args = nil
+		}
+
+		b.WriteByte(' ')
+		b.WriteString(key)
+		b.WriteByte('=')
+		b.WriteString(quoteValue(fmt.Sprint(value)))
+	}
+
+	l.logger.Print(b.String())
+}
+
+// quoteValue заключает значение в кавычки, если без них строку нельзя разобрать.
+func quoteValue(s string) string {
+	if s == "" || strings.ContainsAny(s, " =\"\t\n") {
+		return strconv.Quote(s)
+	}
+
+	return s
+}
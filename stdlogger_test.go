@@ -0,0 +1,26 @@
+package go_runner
+
+import (
+	"bytes"
+	"errors"
+	"log"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStdLogger(t *testing.T) {
+	var buf bytes.Buffer
+	logger := NewStdLogger(log.New(&buf, "", 0))
+
+	logger.Info("application started", "app", "api")
+	logger.Debug("draining before stop", "delay", "5s")
+	logger.Warn("application drain error", "app", "api", "error", errors.New("connection reset"))
+	logger.Error("terminating with error", "error", errors.New("boom"), "orphan")
+
+	assert.Equal(t, `INFO application started app=api
+DEBUG draining before stop delay=5s
+WARN application drain error app=api error="connection reset"
+ERROR terminating with error error=boom !BADKEY=orphan
+`, buf.String())
+}
@@ -0,0 +1,30 @@
+package go_runner
+
+import "slices"
+
+// Stragglers возвращает имена приложений, Stop которых не вернул управление до
+// истечения времени остановки или ее прерывания в последнем запуске.
+func (r *Runner) Stragglers() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	return slices.Clone(r.stragglers)
+}
+
+// reportStragglers фиксирует и логирует приложения, Stop которых вызван, но
+// еще не вернул управление.
+func (r *Runner) reportStragglers() {
+	r.mu.Lock()
+	var stragglers []int
+	for i, st := range r.states {
+		if st.stopCalled && !st.stopped {
+			stragglers = append(stragglers, i)
+			r.stragglers = append(r.stragglers, r.label(i))
+		}
+	}
+	r.mu.Unlock()
+
+	for _, i := range stragglers {
+		r.logger.Warn(r.messages.StopStraggler, r.appFields(i)...)
+	}
+}
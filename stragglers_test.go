@@ -0,0 +1,38 @@
+package go_runner
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunner_Stragglers(t *testing.T) {
+	stopErr := errors.New("stop error")
+	hung := make(chan struct{})
+	defer close(hung)
+
+	runner := New(discardLogger{}, WithParallelStop(0), WithShutdownTimeout(50*time.Millisecond))
+	runner.RegisterStartStop("fast", func() error { return nil }, func() error { return nil })
+	runner.RegisterStartStop("failed", func() error { return nil }, func() error { return stopErr })
+	for _, name := range []string{"hung1", "hung2"} {
+		runner.RegisterStartStop(name, func() error { return nil }, func() error {
+			<-hung
+			return nil
+		})
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	err := runner.Run(ctx)
+	require.ErrorIs(t, err, ErrShutdownTimeout)
+	require.ErrorIs(t, err, stopErr)
+	assert.ElementsMatch(t, []string{"hung1", "hung2"}, runner.Stragglers())
+}
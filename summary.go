@@ -0,0 +1,111 @@
+package go_runner
+
+import "time"
+
+// Итоговый статус запуска
+const (
+	RunStatusNotRun    RunStatus = "not_run"
+	RunStatusRunning   RunStatus = "running"
+	RunStatusSucceeded RunStatus = "succeeded"
+	RunStatusFailed    RunStatus = "failed"
+)
+
+type (
+	// RunStatus итоговый статус запуска
+	RunStatus string
+
+	// Summary отчет о запуске приложений
+	Summary struct {
+		Status   RunStatus     `json:"status"`
+		Error    string        `json:"error,omitempty"`
+		Duration time.Duration `json:"duration"`
+		Apps     []AppSummary  `json:"apps"`
+	}
+
+	// AppSummary отчет о запуске отдельного приложения
+	AppSummary struct {
+		Name          string        `json:"name"`
+		Started       bool          `json:"started"`
+		StartDuration time.Duration `json:"start_duration"`
+		StartError    string        `json:"start_error,omitempty"`
+		StopError     string        `json:"stop_error,omitempty"`
+	}
+)
+
+// Summary возвращает отчет о последнем запуске. Во время работы Run отчет
+// отражает текущее состояние, после возврата из Run — итоговое.
+func (r *Runner) Summary() Summary {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	s := Summary{
+		Status: RunStatusNotRun,
+		Apps:   make([]AppSummary, 0, len(r.states)),
+	}
+
+	if r.states == nil {
+		return s
+	}
+
+	switch {
+	case r.lifecycle != StateStopped:
+		s.Status = RunStatusRunning
+		s.Duration = time.Since(r.startedAt)
+	case r.err != nil:
+		s.Status = RunStatusFailed
+		s.Error = r.err.Error()
+		s.Duration = r.duration
+	default:
+		s.Status = RunStatusSucceeded
+		s.Duration = r.duration
+	}
+
+	for i, st := range r.states {
+		// Shutdown hook не является приложением
+		if r.apps[i].Start == nil {
+			continue
+		}
+
+		as := AppSummary{
+			Name:          r.apps[i].Name,
+			Started:       st.started,
+			StartDuration: st.startDuration,
+		}
+		if st.startErr != nil {
+			as.StartError = st.startErr.Error()
+		}
+		if st.stopErr != nil {
+			as.StopError = st.stopErr.Error()
+		}
+
+		s.Apps = append(s.Apps, as)
+	}
+
+	return s
+}
+
+// summaryFields возвращает поля итоговой строки лога: число запущенных приложений,
+// ошибок запуска и остановки и длительность запуска.
+func (r *Runner) summaryFields() []any {
+	s := r.Summary()
+
+	var started, startFailed, stopFailed int
+	for _, a := range s.Apps {
+		if a.Started {
+			started++
+		}
+		if a.StartError != "" {
+			startFailed++
+		}
+		if a.StopError != "" {
+			stopFailed++
+		}
+	}
+
+	return []any{
+		r.messages.StartedKey, started,
+		r.messages.StartFailedKey, startFailed,
+		r.messages.StopFailedKey, stopFailed,
+		r.messages.DurationKey, s.Duration,
+	}
+}
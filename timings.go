@@ -0,0 +1,54 @@
+package go_runner
+
+import "time"
+
+type (
+	// Timings длительности последнего запуска
+	Timings struct {
+		// Total общее время работы Run
+		Total time.Duration
+		Apps  []AppTiming
+	}
+
+	// AppTiming длительности Start и Stop отдельного приложения
+	AppTiming struct {
+		Name  string
+		Start time.Duration
+		Stop  time.Duration
+	}
+)
+
+// Timings возвращает длительности Start и Stop приложений и общее время работы
+// последнего запуска. Во время работы Run Total отражает прошедшее время.
+func (r *Runner) Timings() Timings {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	t := Timings{
+		Apps: make([]AppTiming, 0, len(r.states)),
+	}
+
+	if r.states == nil {
+		return t
+	}
+
+	t.Total = r.duration
+	if r.lifecycle != StateStopped {
+		t.Total = time.Since(r.startedAt)
+	}
+
+	for i, st := range r.states {
+		// Shutdown hook не является приложением
+		if r.apps[i].Start == nil {
+			continue
+		}
+
+		t.Apps = append(t.Apps, AppTiming{
+			Name:  r.apps[i].Name,
+			Start: st.startDuration,
+			Stop:  st.stopDuration,
+		})
+	}
+
+	return t
+}
@@ -0,0 +1,43 @@
+package go_runner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAppsRunner_Timings(t *testing.T) {
+	recorder := &callRecorder{}
+
+	runner := New(discardLogger{})
+	assert.Empty(t, runner.Timings().Apps)
+
+	runner.RegisterNamedApp("db", &recordingApp{
+		name:       "db",
+		recorder:   recorder,
+		startDelay: 30 * time.Millisecond,
+		stopDelay:  60 * time.Millisecond,
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		assert.Eventually(t, func() bool { return allStarted(runner) }, time.Second, time.Millisecond)
+		cancel()
+	}()
+
+	require.NoError(t, runner.Run(ctx))
+
+	timings := runner.Timings()
+	require.Len(t, timings.Apps, 1)
+
+	app := timings.Apps[0]
+	assert.Equal(t, "db", app.Name)
+	assert.GreaterOrEqual(t, app.Start, 30*time.Millisecond)
+	assert.Less(t, app.Start, 500*time.Millisecond)
+	assert.GreaterOrEqual(t, app.Stop, 60*time.Millisecond)
+	assert.Less(t, app.Stop, 500*time.Millisecond)
+	assert.GreaterOrEqual(t, timings.Total, app.Start+app.Stop)
+}
@@ -0,0 +1,41 @@
+// Package zapadapter адаптирует go.uber.org/zap к интерфейсу go_runner.Logger.
+// Пакет вынесен в отдельный модуль, чтобы не добавлять зависимость от zap
+// пользователям go-runner, которые ее не используют.
+package zapadapter
+
+import (
+	go_runner "github.com/aatumaykin/go-runner"
+	"go.uber.org/zap"
+)
+
+// ZapLogger логгер, передающий сообщения в *zap.SugaredLogger. Пары ключ-значение
+// из args становятся полями записи.
+type ZapLogger struct {
+	logger *zap.SugaredLogger
+}
+
+// NewZapLogger создает адаптер поверх указанного *zap.SugaredLogger.
+func NewZapLogger(logger *zap.SugaredLogger) go_runner.Logger {
+	return &ZapLogger{logger: logger}
+}
+
+func (l *ZapLogger) Debug(msg string, args ...any) {
+	l.logger.Debugw(msg, args...)
+}
+
+func (l *ZapLogger) Error(msg string, args ...any) {
+	l.logger.Errorw(msg, args...)
+}
+
+func (l *ZapLogger) Info(msg string, args ...any) {
+	l.logger.Infow(msg, args...)
+}
+
+func (l *ZapLogger) Warn(msg string, args ...any) {
+	l.logger.Warnw(msg, args...)
+}
+
+// Sync сбрасывает буфер zap. Runner вызывает его последним действием Run.
+func (l *ZapLogger) Sync() error {
+	return l.logger.Sync()
+}
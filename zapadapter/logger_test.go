@@ -0,0 +1,38 @@
+package zapadapter
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestZapLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := NewZapLogger(zap.New(core).Sugar())
+
+	stopErr := errors.New("stop error")
+	logger.Debug("start application", "app", "db")
+	logger.Info("application was stopped")
+	logger.Warn("reload ignored during startup")
+	logger.Error("application stop error", "app", "db", "error", stopErr)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "start application", entries[0].Message)
+	assert.Equal(t, map[string]any{"app": "db"}, entries[0].ContextMap())
+
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+	assert.Empty(t, entries[1].Context)
+
+	assert.Equal(t, zapcore.WarnLevel, entries[2].Level)
+
+	assert.Equal(t, zapcore.ErrorLevel, entries[3].Level)
+	assert.Equal(t, map[string]any{"app": "db", "error": stopErr.Error()}, entries[3].ContextMap())
+}